@@ -0,0 +1,558 @@
+// Copyright (C) 2026 Michael J. Fromberger. All Rights Reserved.
+
+package semver
+
+import (
+	"cmp"
+	"container/heap"
+	"iter"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Sort sorts vs in place in ascending order by [Compare]. The sort is stable,
+// so versions that are equivalent but differ in build metadata retain their
+// relative order from the input.
+//
+// To sort with other functions from the [slices] package, note that Compare
+// has the signature required by [slices.SortFunc].
+func Sort(vs []V) { slices.SortStableFunc(vs, Compare) }
+
+// Versions is a slice of versions that implements [sort.Interface], ordering
+// versions by [Compare]. Thus prereleases precede the corresponding release,
+// and build metadata are ignored. Use [sort.Stable] to preserve the input
+// order of versions that differ only in build metadata:
+//
+//	sort.Stable(semver.Versions(vs))
+type Versions []V
+
+func (vs Versions) Len() int           { return len(vs) }
+func (vs Versions) Less(i, j int) bool { return Compare(vs[i], vs[j]) < 0 }
+func (vs Versions) Swap(i, j int)      { vs[i], vs[j] = vs[j], vs[i] }
+
+// SortDescending sorts vs in place in descending order by [Compare]. Like
+// [Sort], the sort is stable.
+func SortDescending(vs []V) {
+	slices.SortStableFunc(vs, func(a, b V) int { return Compare(b, a) })
+}
+
+// Search returns the index of the first element of sorted that is not less
+// than target by [Compare], or len(sorted) if there is no such element. This
+// is the position at which target would be inserted to keep sorted in order.
+// The elements of sorted must be in ascending order by Compare, as produced
+// by [Sort]; otherwise the result is unspecified.
+func Search(sorted []V, target V) int {
+	i, _ := slices.BinarySearchFunc(sorted, target, Compare)
+	return i
+}
+
+// Contains reports whether sorted contains a version equivalent to target by
+// [Compare]. As with [Search], sorted must be in ascending order by Compare.
+func Contains(sorted []V, target V) bool {
+	_, ok := slices.BinarySearchFunc(sorted, target, Compare)
+	return ok
+}
+
+// Min returns the least of the given versions by [Compare]. If several are
+// equivalent and least, the first of them is returned.
+// Min panics if no versions are given.
+func Min(vs ...V) V {
+	if len(vs) == 0 {
+		panic("semver.Min: no versions")
+	}
+	out := vs[0]
+	for _, v := range vs[1:] {
+		if v.Before(out) {
+			out = v
+		}
+	}
+	return out
+}
+
+// Max returns the greatest of the given versions by [Compare]. If several are
+// equivalent and greatest, the first of them is returned.
+// Max panics if no versions are given.
+func Max(vs ...V) V {
+	if len(vs) == 0 {
+		panic("semver.Max: no versions")
+	}
+	out := vs[0]
+	for _, v := range vs[1:] {
+		if v.After(out) {
+			out = v
+		}
+	}
+	return out
+}
+
+// Latest returns the greatest version in vs by [Compare], as [Max], and
+// reports whether vs is non-empty.
+func Latest(vs []V) (V, bool) {
+	if len(vs) == 0 {
+		return V{}, false
+	}
+	return Max(vs...), true
+}
+
+// LatestStable returns the greatest version in vs by [Compare] for which
+// [V.IsStable] is true, and reports whether any such version was found.
+// Note that this excludes prereleases and versions with major version 0.
+// If several are equivalent and greatest, the first is returned.
+func LatestStable(vs []V) (V, bool) {
+	var out V
+	var found bool
+	for _, v := range vs {
+		if v.IsStable() && (!found || v.After(out)) {
+			out, found = v, true
+		}
+	}
+	return out, found
+}
+
+// HighestString cleans and parses each element of ss as [ParseClean], and
+// returns the greatest of the resulting versions. Elements that are not valid
+// after cleaning are ignored. It reports false if ss contains no valid
+// versions. If several are equivalent and greatest, the first is returned.
+func HighestString(ss []string) (V, bool) { return extremeString(ss, V.After) }
+
+// LowestString cleans and parses each element of ss as [ParseClean], and
+// returns the least of the resulting versions. Elements that are not valid
+// after cleaning are ignored. It reports false if ss contains no valid
+// versions. If several are equivalent and least, the first is returned.
+func LowestString(ss []string) (V, bool) { return extremeString(ss, V.Before) }
+
+// extremeString returns the first valid version v in ss such that no other
+// valid version w in ss has better(w, v).
+func extremeString(ss []string, better func(a, b V) bool) (V, bool) {
+	var out V
+	var found bool
+	for _, s := range ss {
+		v, err := ParseClean(s)
+		if err == nil && (!found || better(v, out)) {
+			out, found = v, true
+		}
+	}
+	return out, found
+}
+
+// SortStrings sorts ss in place in ascending order by [CompareStrings]. The
+// sort is stable. Strings that are not valid versions after cleaning are
+// ordered after all valid ones, in lexicographic order, so that the result
+// does not depend on the input order when the two kinds are mixed.
+func SortStrings(ss []string) {
+	es := make([]stringVersion, len(ss))
+	for i, s := range ss {
+		es[i] = newStringVersion(s)
+	}
+	slices.SortStableFunc(es, func(a, b stringVersion) int {
+		switch {
+		case a.ok && b.ok:
+			return Compare(a.v, b.v)
+		case a.ok != b.ok:
+			return cond(a.ok, -1, 1)
+		}
+		return cmp.Compare(a.s, b.s)
+	})
+	for i, e := range es {
+		ss[i] = e.s
+	}
+}
+
+// UniqueStrings returns the distinct versions in ss, cleaned as [Clean] and
+// sorted in ascending order by [Compare]. Strings that are not valid versions
+// after cleaning are discarded. Of several equivalent versions, such as
+// versions that differ only in build metadata, only the first is retained.
+func UniqueStrings(ss []string) []string {
+	var es []stringVersion
+	for _, s := range ss {
+		if e := newStringVersion(s); e.ok {
+			es = append(es, e)
+		}
+	}
+	slices.SortStableFunc(es, func(a, b stringVersion) int {
+		return Compare(a.v, b.v)
+	})
+	es = slices.CompactFunc(es, func(a, b stringVersion) bool {
+		return Compare(a.v, b.v) == 0
+	})
+	out := make([]string, len(es))
+	for i, e := range es {
+		out[i] = e.clean
+	}
+	return out
+}
+
+// A stringVersion is a string paired with its parsed version, if valid.
+type stringVersion struct {
+	s, clean string
+	v        V
+	ok       bool
+}
+
+func newStringVersion(s string) stringVersion {
+	v, clean, err := parseClean(s)
+	return stringVersion{s: s, clean: clean, v: v, ok: err == nil}
+}
+
+// Resolve returns the greatest element of known that matches pin, and reports
+// whether any such element was found.
+//
+// A pin is a version of the form major[.minor[.patch]], optionally with a
+// leading "v" and surrounding whitespace. A pin that specifies fewer than
+// three core fields is a prefix: "1.2" matches any version whose major and
+// minor versions are 1 and 2, including prereleases. A pin that specifies all
+// three core fields (possibly with a release or build label) is exact, and
+// matches only versions equivalent to it (see [V.Equiv]).
+//
+// If several elements of known are equivalent and greatest, Resolve returns
+// the first of them.
+func Resolve(pin string, known []V) (V, bool) {
+	want, depth, ok := parsePin(pin)
+	if !ok {
+		return V{}, false
+	}
+	var best V
+	var found bool
+	for _, v := range known {
+		if !pinMatch(want, depth, v) {
+			continue
+		}
+		if !found || Compare(v, best) > 0 {
+			best, found = v, true
+		}
+	}
+	return best, found
+}
+
+// parsePin parses a pin string for [Resolve]. It returns the version
+// described by the pin, the number of core fields specified, and whether the
+// pin was valid.
+func parsePin(pin string) (V, int, bool) {
+	v, n, err := parsePartial(strings.TrimPrefix(strings.TrimSpace(pin), "v"))
+	return v, n, err == nil
+}
+
+// pinMatch reports whether v matches the first depth fields of want.
+// If depth == 3, v must be equivalent to want.
+func pinMatch(want V, depth int, v V) bool {
+	switch depth {
+	case 3:
+		return want.Equiv(v)
+	case 2:
+		if compareNum(v.minor, want.minor) != 0 {
+			return false
+		}
+		fallthrough
+	default:
+		return compareNum(v.major, want.major) == 0
+	}
+}
+
+// A MaxTracker records the greatest version observed in a sequence of
+// versions, without retaining the rest of the sequence. Versions that are
+// equivalent are ordered by their build metadata as [CompareFull], so that
+// the result does not depend on the order of observation. A zero MaxTracker
+// is ready for use. It is safe to use a MaxTracker concurrently from multiple
+// goroutines.
+type MaxTracker struct {
+	μ   sync.Mutex
+	max V
+	n   int
+}
+
+// Observe records the observation of v.
+func (m *MaxTracker) Observe(v V) {
+	m.μ.Lock()
+	defer m.μ.Unlock()
+	if m.n == 0 || CompareFull(v, m.max) > 0 {
+		m.max = v
+	}
+	m.n++
+}
+
+// Max reports the greatest version observed so far, and whether any versions
+// have been observed.
+func (m *MaxTracker) Max() (V, bool) {
+	m.μ.Lock()
+	defer m.μ.Unlock()
+	return m.max, m.n != 0
+}
+
+// Count reports the number of versions observed so far.
+func (m *MaxTracker) Count() int {
+	m.μ.Lock()
+	defer m.μ.Unlock()
+	return m.n
+}
+
+// Reset discards all observations, restoring m to its initial state.
+func (m *MaxTracker) Reset() {
+	m.μ.Lock()
+	defer m.μ.Unlock()
+	m.max, m.n = V{}, 0
+}
+
+// Partition splits vs into the versions at or above floor (supported) and the
+// versions below floor (eol), each in the order they occur in vs. A version
+// equivalent to floor is supported. Note that a prerelease of the same core
+// as floor precedes floor, so for floor 2.0.0, 2.0.0-rc1 is eol.
+func Partition(vs []V, floor V) (supported, eol []V) {
+	for _, v := range vs {
+		if v.Before(floor) {
+			eol = append(eol, v)
+		} else {
+			supported = append(supported, v)
+		}
+	}
+	return
+}
+
+// FilterFunc returns a new slice of the elements of vs for which keep
+// reports true, in the order they occur in vs.
+func FilterFunc(vs []V, keep func(V) bool) []V {
+	var out []V
+	for _, v := range vs {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// FilterStable returns a new slice of the elements of vs for which
+// [V.IsStable] is true, in the order they occur in vs.
+func FilterStable(vs []V) []V { return FilterFunc(vs, V.IsStable) }
+
+// FilterPrerelease returns a new slice of the elements of vs that have a
+// release label (see [V.IsPrerelease]), in the order they occur in vs.
+func FilterPrerelease(vs []V) []V { return FilterFunc(vs, V.IsPrerelease) }
+
+// ReleasesBehind reports how many distinct versions in released are strictly
+// after current. Equivalent versions are counted once, and released need not
+// be sorted. If countPrereleases is false, versions in released that have a
+// release label are not counted. Note that current itself need not be an
+// element of released.
+func ReleasesBehind(current V, released []V, countPrereleases bool) int {
+	seen := make(map[MapKey]bool)
+	for _, v := range released {
+		if v.After(current) && (countPrereleases || v.release == "") {
+			seen[v.AsMapKey()] = true
+		}
+	}
+	return len(seen)
+}
+
+// Rank reports the position of v in catalog, counting distinct versions from
+// newest to oldest, so that the newest version has rank 0, the next newest 1,
+// and so on. Equivalent versions share the same rank. If no element of catalog
+// is equivalent to v, Rank reports (-1, false). The catalog need not be sorted.
+func Rank(v V, catalog []V) (int, bool) {
+	for _, c := range catalog {
+		if c.Equiv(v) {
+			return ReleasesBehind(v, catalog, true), true
+		}
+	}
+	return -1, false
+}
+
+// FindDuplicates returns the groups of mutually equivalent elements of vs
+// that have more than one member (see [V.Equiv]). Within each group, elements
+// are in the order they occur in vs, and the groups are ordered by their first
+// occurrence in vs. If vs has no duplicates, FindDuplicates returns nil.
+func FindDuplicates(vs []V) [][]V {
+	groups := make(map[MapKey][]V)
+	var keys []MapKey
+	for _, v := range vs {
+		k := v.AsMapKey()
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], v)
+	}
+	var out [][]V
+	for _, k := range keys {
+		if g := groups[k]; len(g) > 1 {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// GroupBy partitions vs into groups of elements having equivalent keys, as
+// computed by key (see [V.Equiv]). Within each group, elements are in the
+// order they occur in vs, and the groups are in ascending order of their keys
+// by [Compare]. For example, to group versions by release series:
+//
+//	GroupBy(vs, func(v V) V { return New(v.Major(), v.Minor(), 0) })
+//
+// If vs is empty, GroupBy returns nil.
+func GroupBy(vs []V, key func(V) V) [][]V {
+	groups := make(map[MapKey][]V)
+	var keys []V
+	for _, v := range vs {
+		k := key(v)
+		mk := k.AsMapKey()
+		if _, ok := groups[mk]; !ok {
+			keys = append(keys, k)
+		}
+		groups[mk] = append(groups[mk], v)
+	}
+	Sort(keys)
+	var out [][]V
+	for _, k := range keys {
+		out = append(out, groups[k.AsMapKey()])
+	}
+	return out
+}
+
+// LatestPatchPerMinor returns the greatest version by [Compare] for each
+// distinct major.minor version in vs, in ascending order. If several are
+// equivalent and greatest, the first is returned.
+//
+// Because a prerelease precedes the release of the same core version, a
+// stable release is chosen over its own prereleases; but a prerelease of a
+// higher patch version is chosen over a release of a lower one. Thus from
+// 1.2.3, 1.2.4-rc1, and 1.2.4 the result is 1.2.4, but from 1.2.3 and
+// 1.2.4-rc1 it is 1.2.4-rc1. Use [FilterStable] first to exclude prereleases.
+func LatestPatchPerMinor(vs []V) []V {
+	groups := GroupBy(vs, V.MajorMinor)
+	var out []V
+	for _, g := range groups {
+		out = append(out, Max(g...))
+	}
+	return out
+}
+
+// PatchRange returns an iterator over the patch versions from lo to hi,
+// inclusive: lo, lo.IncPatch(), lo.IncPatch().IncPatch(), and so on, while
+// the version is not after hi. For example, PatchRange(1.2.0, 1.2.5) yields
+// 1.2.0, 1.2.1, ..., 1.2.5.
+//
+// The first version yielded is lo itself, including any release or build
+// metadata. The rest are stable core versions (see [V.IncPatch]). Thus if lo
+// is a prerelease, the stable release of its core is skipped: PatchRange of
+// 1.2.3-rc.1 and 1.2.4 yields 1.2.3-rc.1 and 1.2.4. Likewise if hi is a
+// prerelease, the stable release of its core is not reached.
+//
+// If lo and hi do not share the same major and minor versions, or if lo is
+// after hi, the sequence is empty.
+func PatchRange(lo, hi V) iter.Seq[V] {
+	return stepRange(lo, hi, CompareMinor(lo, hi) == 0, V.IncPatch)
+}
+
+// MinorRange returns an iterator over the minor versions from lo to hi,
+// inclusive: lo, lo.IncMinor(), lo.IncMinor().IncMinor(), and so on, while
+// the version is not after hi. For example, MinorRange(1.2.3, 1.5.0) yields
+// 1.2.3, 1.3.0, 1.4.0, 1.5.0.
+//
+// As with [PatchRange], the first version yielded is lo itself, and the rest
+// are stable core versions (see [V.IncMinor]). If lo and hi do not share the
+// same major version, or if lo is after hi, the sequence is empty.
+func MinorRange(lo, hi V) iter.Seq[V] {
+	return stepRange(lo, hi, compareNum(lo.major, hi.major) == 0, V.IncMinor)
+}
+
+// stepRange returns an iterator that yields lo, next(lo), next(next(lo)),
+// and so on while the value is not after hi. If ok is false, the sequence is
+// empty.
+func stepRange(lo, hi V, ok bool, next func(V) V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		if !ok {
+			return
+		}
+		for v := lo; !v.After(hi); v = next(v) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SelectMVS returns the version selected by [minimal version selection] for a
+// single module, given the minimum versions required of it. This is the
+// greatest of the requirements; if several are equivalent and greatest, the
+// first of them is returned. If requirements is empty, SelectMVS returns the
+// zero [V].
+//
+// [minimal version selection]: https://research.swtch.com/vgo-mvs
+func SelectMVS(requirements []V) V {
+	if len(requirements) == 0 {
+		return V{}
+	}
+	return Max(requirements...)
+}
+
+// Predecessor returns the greatest element of catalog that is strictly before
+// v, and reports whether one was found. The catalog need not be sorted. If
+// several elements are equivalent and greatest, the first of them is returned.
+func Predecessor(v V, catalog []V) (V, bool) {
+	var best V
+	var found bool
+	for _, c := range catalog {
+		if c.Before(v) && (!found || c.After(best)) {
+			best, found = c, true
+		}
+	}
+	return best, found
+}
+
+// MergeAll merges the given streams, each of which must already be sorted in
+// ascending order by [Compare], into a single sorted slice. Equivalent
+// versions are collapsed, keeping only the first occurrence, where the
+// earlier stream is considered first when several streams contain
+// equivalent versions. If any stream is not sorted, the result is unspecified.
+func MergeAll(streams ...[]V) []V {
+	var h mergeHeap
+	for i, s := range streams {
+		if len(s) != 0 {
+			h = append(h, mergeCursor{stream: i, rest: s})
+		}
+	}
+	heap.Init(&h)
+
+	var out []V
+	for len(h) != 0 {
+		next := h[0].rest[0]
+		if n := len(out); n == 0 || !out[n-1].Equiv(next) {
+			out = append(out, next)
+		}
+		if h[0].rest = h[0].rest[1:]; len(h[0].rest) == 0 {
+			heap.Pop(&h)
+		} else {
+			heap.Fix(&h, 0)
+		}
+	}
+	return out
+}
+
+// A mergeCursor is the unconsumed portion of one input to [MergeAll].
+type mergeCursor struct {
+	stream int // index of the stream, for tie-breaking
+	rest   []V // non-empty
+}
+
+// mergeHeap implements [heap.Interface] over cursors, ordered by their next
+// version and then by stream index.
+type mergeHeap []mergeCursor
+
+func (h mergeHeap) Len() int { return len(h) }
+
+func (h mergeHeap) Less(i, j int) bool {
+	if c := Compare(h[i].rest[0], h[j].rest[0]); c != 0 {
+		return c < 0
+	}
+	return h[i].stream < h[j].stream
+}
+
+func (h mergeHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *mergeHeap) Push(x any) { *h = append(*h, x.(mergeCursor)) }
+
+func (h *mergeHeap) Pop() any {
+	old := *h
+	n := len(old) - 1
+	out := old[n]
+	*h = old[:n]
+	return out
+}
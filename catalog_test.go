@@ -0,0 +1,573 @@
+// Copyright (C) 2026 Michael J. Fromberger. All Rights Reserved.
+
+package semver_test
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/creachadair/mds/mtest"
+	"github.com/creachadair/semver"
+)
+
+func mustParseAll(t *testing.T, ss ...string) []semver.V {
+	t.Helper()
+	out := make([]semver.V, len(ss))
+	for i, s := range ss {
+		out[i] = mustParse(t, s)
+	}
+	return out
+}
+
+func TestSort(t *testing.T) {
+	input := []string{
+		"1.0.0+b", "0.9.0", "1.0.0-rc.1", "1.0.0+a", "2.0.0", "1.0.0-rc.10", "1.0.0", "1.0.0-rc.2",
+	}
+	vs := mustParseAll(t, input...)
+	semver.Sort(vs)
+	checkVersions(t, "Sort", vs,
+		"0.9.0", "1.0.0-rc.1", "1.0.0-rc.2", "1.0.0-rc.10", "1.0.0+b", "1.0.0+a", "1.0.0", "2.0.0",
+	)
+
+	vs = mustParseAll(t, input...)
+	semver.SortDescending(vs)
+	checkVersions(t, "SortDescending", vs,
+		"2.0.0", "1.0.0+b", "1.0.0+a", "1.0.0", "1.0.0-rc.10", "1.0.0-rc.2", "1.0.0-rc.1", "0.9.0",
+	)
+
+	semver.Sort(nil) // must not panic
+
+	vs = mustParseAll(t, input...)
+	sort.Stable(semver.Versions(vs))
+	checkVersions(t, "sort.Stable", vs,
+		"0.9.0", "1.0.0-rc.1", "1.0.0-rc.2", "1.0.0-rc.10", "1.0.0+b", "1.0.0+a", "1.0.0", "2.0.0",
+	)
+
+	vs = mustParseAll(t, input...)
+	sort.Sort(sort.Reverse(semver.Versions(vs)))
+	if !slices.IsSortedFunc(vs, func(a, b semver.V) int { return semver.Compare(b, a) }) {
+		t.Errorf("sort.Reverse: got %v, want descending order", vs)
+	}
+}
+
+func TestSortStrings(t *testing.T) {
+	input := []string{
+		"v1.10.0", "1.2x", "1.9.0", "bogus", "1.0.0+b", "1.0", " 1.0.0-rc1 ", "", "v1.0.0+a", "1.9.0+z",
+	}
+	ss := slices.Clone(input)
+	semver.SortStrings(ss)
+	want := []string{
+		" 1.0.0-rc1 ", "1.0.0+b", "1.0", "v1.0.0+a", "1.9.0", "1.9.0+z", "v1.10.0", "", "1.2x", "bogus",
+	}
+	if !slices.Equal(ss, want) {
+		t.Errorf("SortStrings:\n got %q\nwant %q", ss, want)
+	}
+
+	// The result does not depend on the input order.
+	slices.Reverse(input)
+	semver.SortStrings(input)
+	if !slices.Equal(input[len(input)-3:], want[len(want)-3:]) {
+		t.Errorf("SortStrings reversed: got %q, want invalid entries last", input)
+	}
+
+	semver.SortStrings(nil) // must not panic
+}
+
+func TestUniqueStrings(t *testing.T) {
+	tests := []struct {
+		input []string
+		want  []string
+	}{
+		{nil, []string{}},
+		{[]string{"bogus", ""}, []string{}},
+		{[]string{"1.0.0", "v1.0.0", "1.0"}, []string{"1.0.0"}},
+		{[]string{"v1.0+b", "1.0.0+a", "1.0.0"}, []string{"1.0.0+b"}},
+		{[]string{"2", "v1.10", "1.9.0", "1.9.0-rc1", "x", "1.10.0+meta"},
+			[]string{"1.9.0-rc1", "1.9.0", "1.10.0", "2.0.0"}},
+	}
+	for _, tc := range tests {
+		if got := semver.UniqueStrings(tc.input); !slices.Equal(got, tc.want) {
+			t.Errorf("UniqueStrings(%q): got %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestLatest(t *testing.T) {
+	tests := []struct {
+		input          []string
+		latest, stable string // "" for none
+	}{
+		{nil, "", ""},
+		{[]string{"0.1.0", "0.9.0-rc1"}, "0.9.0-rc1", ""},
+		{[]string{"1.0.0", "2.0.0-rc1", "1.5.0"}, "2.0.0-rc1", "1.5.0"},
+		{[]string{"1.2.0+a", "1.2.0+b", "0.5.0"}, "1.2.0+a", "1.2.0+a"},
+		{[]string{"3.0.0", "2.9.0"}, "3.0.0", "3.0.0"},
+	}
+	for _, tc := range tests {
+		vs := mustParseAll(t, tc.input...)
+		check := func(name string, got semver.V, ok bool, want string) {
+			t.Helper()
+			if want == "" {
+				if ok {
+					t.Errorf("%s(%q): got %v, want none", name, tc.input, got)
+				}
+			} else if !ok || got.String() != want {
+				t.Errorf("%s(%q): got (%v, %v), want %q", name, tc.input, got, ok, want)
+			}
+		}
+		v, ok := semver.Latest(vs)
+		check("Latest", v, ok, tc.latest)
+		v, ok = semver.LatestStable(vs)
+		check("LatestStable", v, ok, tc.stable)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	sorted := mustParseAll(t, "0.9.0", "1.0.0-rc.1", "1.0.0", "1.0.0+b", "1.2.0", "2.0.0")
+	tests := []struct {
+		target string
+		want   int
+		found  bool
+	}{
+		{"0.1.0", 0, false},
+		{"0.9.0", 0, true},
+		{"1.0.0-rc.0", 1, false},
+		{"1.0.0-rc.1", 1, true},
+		{"1.0.0-rc.2", 2, false},
+		{"1.0.0+x", 2, true},
+		{"1.1.0", 4, false},
+		{"2.0.0", 5, true},
+		{"3.0.0", 6, false},
+	}
+	for _, tc := range tests {
+		v := mustParse(t, tc.target)
+		if got := semver.Search(sorted, v); got != tc.want {
+			t.Errorf("Search(%v): got %d, want %d", v, got, tc.want)
+		}
+		if got := semver.Contains(sorted, v); got != tc.found {
+			t.Errorf("Contains(%v): got %v, want %v", v, got, tc.found)
+		}
+	}
+	if got := semver.Search(nil, semver.V{}); got != 0 {
+		t.Errorf("Search(nil): got %d, want 0", got)
+	}
+	if semver.Contains(nil, semver.V{}) {
+		t.Error("Contains(nil): got true, want false")
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	tests := []struct {
+		input    []string
+		min, max string
+	}{
+		{[]string{"1.2.3"}, "1.2.3", "1.2.3"},
+		{[]string{"1.2.3", "1.2.4"}, "1.2.3", "1.2.4"},
+		{[]string{"2.0.0", "1.0.0-rc1", "1.0.0", "0.1.0-x", "1.5.0"}, "0.1.0-x", "2.0.0"},
+		{[]string{"1.0.0+b", "1.0.0+a", "1.0.0"}, "1.0.0+b", "1.0.0+b"},
+		{[]string{"0.1.0", "1.0.0+a", "0.1.0+c", "1.0.0+b"}, "0.1.0", "1.0.0+a"},
+	}
+	for _, tc := range tests {
+		vs := mustParseAll(t, tc.input...)
+		if got := semver.Min(vs...); got.String() != tc.min {
+			t.Errorf("Min(%q): got %v, want %q", tc.input, got, tc.min)
+		}
+		if got := semver.Max(vs...); got.String() != tc.max {
+			t.Errorf("Max(%q): got %v, want %q", tc.input, got, tc.max)
+		}
+	}
+	mtest.MustPanicf(t, func() { semver.Min() }, "Min with no arguments should panic")
+	mtest.MustPanicf(t, func() { semver.Max() }, "Max with no arguments should panic")
+}
+
+func TestHighestLowestString(t *testing.T) {
+	tests := []struct {
+		input           []string
+		lowest, highest string // "" means not found
+	}{
+		{nil, "", ""},
+		{[]string{"", "bogus", "release-x"}, "", ""},
+		{[]string{"v1.2"}, "1.2.0", "1.2.0"},
+		{[]string{"v1.2.3", "main", "v1.10.0", "1.9", "tmp/v2", "v0.5.0-rc1"}, "0.5.0-rc1", "1.10.0"},
+		{[]string{"v2.0.0+b", "junk", "2.0.0+a", "v1.0.0"}, "1.0.0", "2.0.0+b"},
+	}
+	for _, tc := range tests {
+		lo, ok := semver.LowestString(tc.input)
+		if ok != (tc.lowest != "") || (ok && lo.String() != tc.lowest) {
+			t.Errorf("LowestString(%q): got (%v, %v), want %q", tc.input, lo, ok, tc.lowest)
+		}
+		hi, ok := semver.HighestString(tc.input)
+		if ok != (tc.highest != "") || (ok && hi.String() != tc.highest) {
+			t.Errorf("HighestString(%q): got (%v, %v), want %q", tc.input, hi, ok, tc.highest)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	known := mustParseAll(t,
+		"1.0.0", "1.2.0", "1.2.7", "1.2.10-rc1", "1.2.3+b", "1.3.0", "2.0.0-rc1", "0.9.9",
+	)
+	tests := []struct {
+		pin  string
+		want string
+		ok   bool
+	}{
+		// Prefix matches.
+		{"1", "1.3.0", true},
+		{"v1", "1.3.0", true},
+		{"1.2", "1.2.10-rc1", true},
+		{" v1.2\n", "1.2.10-rc1", true},
+		{"1.3", "1.3.0", true},
+		{"2", "2.0.0-rc1", true},
+		{"0", "0.9.9", true},
+		{"0.9", "0.9.9", true},
+
+		// Exact matches.
+		{"1.2.3", "1.2.3+b", true},
+		{"1.2.3+other", "1.2.3+b", true},
+		{"v1.2.7", "1.2.7", true},
+		{"2.0.0-rc1", "2.0.0-rc1", true},
+
+		// No match.
+		{"1.4", "", false},
+		{"3", "", false},
+		{"1.2.4", "", false},
+		{"2.0.0", "", false},
+
+		// Invalid pins.
+		{"", "", false},
+		{"v", "", false},
+		{"x.2", "", false},
+		{"1.x", "", false},
+		{"01.2", "", false},
+		{"1.2-rc1", "", false},
+		{"1.2.3.4", "", false},
+	}
+	for _, tc := range tests {
+		got, ok := semver.Resolve(tc.pin, known)
+		if ok != tc.ok || (ok && got.String() != tc.want) {
+			t.Errorf("Resolve(%q): got (%v, %v), want (%v, %v)", tc.pin, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestMaxTracker(t *testing.T) {
+	var m semver.MaxTracker
+	if v, ok := m.Max(); ok {
+		t.Errorf("Max of empty tracker: got (%v, true), want false", v)
+	}
+	tests := []struct {
+		input, want string
+	}{
+		{"1.0.0-rc1", "1.0.0-rc1"},
+		{"0.9.0", "1.0.0-rc1"},
+		{"1.0.0-rc2", "1.0.0-rc2"},
+		{"1.0.0", "1.0.0"},
+		{"1.0.0-rc3", "1.0.0"},
+		{"1.0.0+b", "1.0.0+b"}, // build breaks the tie
+		{"1.0.0+a", "1.0.0+b"},
+		{"1.0.0", "1.0.0+b"},
+		{"1.1.0-alpha", "1.1.0-alpha"},
+		{"1.0.5", "1.1.0-alpha"},
+	}
+	for i, tc := range tests {
+		m.Observe(mustParse(t, tc.input))
+		got, ok := m.Max()
+		if !ok || got.String() != tc.want {
+			t.Errorf("Observe %q: got Max (%v, %v), want (%v, true)", tc.input, got, ok, tc.want)
+		}
+		if n := m.Count(); n != i+1 {
+			t.Errorf("Observe %q: got Count %d, want %d", tc.input, n, i+1)
+		}
+	}
+
+	m.Reset()
+	if v, ok := m.Max(); ok {
+		t.Errorf("Max after Reset: got (%v, true), want false", v)
+	}
+	if n := m.Count(); n != 0 {
+		t.Errorf("Count after Reset: got %d, want 0", n)
+	}
+	m.Observe(semver.V{})
+	if v, ok := m.Max(); !ok || v != (semver.V{}) {
+		t.Errorf("Max: got (%v, %v), want (0.0.0, true)", v, ok)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	vs := mustParseAll(t,
+		"2.1.0", "1.9.9", "2.0.0-rc1", "2.0.0", "3.0.0-alpha", "2.0.0+b", "0.1.0", "2.0.1-rc1",
+	)
+	sup, eol := semver.Partition(vs, mustParse(t, "2.0.0"))
+	checkVersions(t, "supported", sup, "2.1.0", "2.0.0", "3.0.0-alpha", "2.0.0+b", "2.0.1-rc1")
+	checkVersions(t, "eol", eol, "1.9.9", "2.0.0-rc1", "0.1.0")
+
+	sup, eol = semver.Partition(nil, semver.V{})
+	checkVersions(t, "supported", sup)
+	checkVersions(t, "eol", eol)
+}
+
+func TestFilter(t *testing.T) {
+	vs := mustParseAll(t, "2.1.0", "0.9.0", "2.0.0-rc1", "1.0.0+b", "0.1.0-alpha", "3.0.0")
+	checkVersions(t, "FilterStable", semver.FilterStable(vs), "2.1.0", "1.0.0+b", "3.0.0")
+	checkVersions(t, "FilterPrerelease", semver.FilterPrerelease(vs), "2.0.0-rc1", "0.1.0-alpha")
+	checkVersions(t, "FilterFunc", semver.FilterFunc(vs, func(v semver.V) bool {
+		return v.Major() == 2
+	}), "2.1.0", "2.0.0-rc1")
+	checkVersions(t, "FilterFunc none", semver.FilterFunc(vs, func(semver.V) bool { return false }))
+	checkVersions(t, "FilterStable nil", semver.FilterStable(nil))
+
+	// The input is not modified.
+	checkVersions(t, "input", vs, "2.1.0", "0.9.0", "2.0.0-rc1", "1.0.0+b", "0.1.0-alpha", "3.0.0")
+}
+
+// checkVersions reports an error if the string representations of got do not
+// match want in order.
+func checkVersions(t *testing.T, label string, got []semver.V, want ...string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s: got %d versions %v, want %d %q", label, len(got), got, len(want), want)
+		return
+	}
+	for i, v := range got {
+		if v.String() != want[i] {
+			t.Errorf("%s [%d]: got %v, want %q", label, i, v, want[i])
+		}
+	}
+}
+
+func TestPatchRange(t *testing.T) {
+	tests := []struct {
+		lo, hi string
+		want   []string
+	}{
+		{"1.2.0", "1.2.5", []string{"1.2.0", "1.2.1", "1.2.2", "1.2.3", "1.2.4", "1.2.5"}},
+		{"1.2.3", "1.2.3", []string{"1.2.3"}},
+		{"1.2.3-rc.1", "1.2.4", []string{"1.2.3-rc.1", "1.2.4"}},
+		{"1.2.3", "1.2.5-rc.1", []string{"1.2.3", "1.2.4"}},
+		{"1.2.3", "1.2.5+b", []string{"1.2.3", "1.2.4", "1.2.5"}},
+		{"1.2.5", "1.2.3", nil},
+		{"1.2.3", "1.3.0", nil},
+		{"1.2.3", "2.2.3", nil},
+	}
+	for _, tc := range tests {
+		lo, hi := mustParse(t, tc.lo), mustParse(t, tc.hi)
+		checkVersions(t, fmt.Sprintf("PatchRange(%v, %v)", lo, hi), slices.Collect(semver.PatchRange(lo, hi)), tc.want...)
+	}
+
+	// Stopping early is respected.
+	var got []semver.V
+	for v := range semver.PatchRange(semver.New(1, 0, 0), semver.New(1, 0, 100)) {
+		if v.Patch() == 3 {
+			break
+		}
+		got = append(got, v)
+	}
+	checkVersions(t, "PatchRange break", got, "1.0.0", "1.0.1", "1.0.2")
+}
+
+func TestMinorRange(t *testing.T) {
+	tests := []struct {
+		lo, hi string
+		want   []string
+	}{
+		{"1.2.3", "1.5.0", []string{"1.2.3", "1.3.0", "1.4.0", "1.5.0"}},
+		{"1.2.3", "1.4.9", []string{"1.2.3", "1.3.0", "1.4.0"}},
+		{"1.2.3", "1.2.9", []string{"1.2.3"}},
+		{"0.9.0", "0.11.0-rc.1", []string{"0.9.0", "0.10.0"}},
+		{"1.5.0", "1.2.0", nil},
+		{"1.2.3", "2.0.0", nil},
+	}
+	for _, tc := range tests {
+		lo, hi := mustParse(t, tc.lo), mustParse(t, tc.hi)
+		checkVersions(t, fmt.Sprintf("MinorRange(%v, %v)", lo, hi), slices.Collect(semver.MinorRange(lo, hi)), tc.want...)
+	}
+}
+
+func TestReleasesBehind(t *testing.T) {
+	released := mustParseAll(t,
+		"1.0.0", "1.1.0", "1.1.0+b", "1.2.0-rc1", "1.2.0", "1.1.0", "2.0.0-beta", "0.9.0",
+	)
+	tests := []struct {
+		current string
+		pre     bool
+		want    int
+	}{
+		{"1.0.0", false, 2},
+		{"1.0.0", true, 4},
+		{"1.0.5", false, 2}, // not itself released
+		{"1.0.5", true, 4},
+		{"1.2.0-rc1", false, 1},
+		{"1.2.0-rc1", true, 2},
+		{"1.2.0", false, 0},
+		{"1.2.0", true, 1},
+		{"2.0.0", true, 0},
+		{"0.0.1", false, 4},
+	}
+	for _, tc := range tests {
+		cur := mustParse(t, tc.current)
+		if got := semver.ReleasesBehind(cur, released, tc.pre); got != tc.want {
+			t.Errorf("ReleasesBehind(%v, %v): got %d, want %d", cur, tc.pre, got, tc.want)
+		}
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	vs := mustParseAll(t, "1.2.3", "2.0.0", "1.2.0-rc1", "1.3.0", "0.1.0", "1.2.3+b", "2.0.1")
+	series := func(v semver.V) semver.V { return semver.New(v.Major(), v.Minor(), 0) }
+	groups := semver.GroupBy(vs, series)
+	want := [][]string{
+		{"0.1.0"},
+		{"1.2.3", "1.2.0-rc1", "1.2.3+b"},
+		{"1.3.0"},
+		{"2.0.0", "2.0.1"},
+	}
+	if len(groups) != len(want) {
+		t.Fatalf("GroupBy: got %d groups %v, want %d", len(groups), groups, len(want))
+	}
+	for i, g := range groups {
+		checkVersions(t, fmt.Sprintf("GroupBy [%d]", i), g, want[i]...)
+	}
+	if got := semver.GroupBy(nil, series); got != nil {
+		t.Errorf("GroupBy(nil): got %v, want nil", got)
+	}
+}
+
+func TestLatestPatchPerMinor(t *testing.T) {
+	tests := []struct {
+		input []string
+		want  []string
+	}{
+		{nil, nil},
+		{[]string{"1.2.3", "1.2.4-rc1"}, []string{"1.2.4-rc1"}},
+		{[]string{"1.2.3", "1.2.4-rc1", "1.2.4"}, []string{"1.2.4"}},
+		{[]string{"1.2.4", "1.2.4-rc1", "1.2.3"}, []string{"1.2.4"}},
+		{[]string{"1.2.4+a", "1.2.4+b"}, []string{"1.2.4+a"}},
+		{[]string{"2.0.5", "1.10.0", "1.9.2", "1.9.10", "0.0.1", "1.10.1-beta"},
+			[]string{"0.0.1", "1.9.10", "1.10.1-beta", "2.0.5"}},
+	}
+	for _, tc := range tests {
+		got := semver.LatestPatchPerMinor(mustParseAll(t, tc.input...))
+		checkVersions(t, fmt.Sprintf("LatestPatchPerMinor(%q)", tc.input), got, tc.want...)
+	}
+}
+
+func TestSelectMVS(t *testing.T) {
+	if got := semver.SelectMVS(nil); got != (semver.V{}) {
+		t.Errorf("SelectMVS(nil): got %v, want zero", got)
+	}
+
+	// Module C is required by A (>= 1.2.0), B (>= 1.3.1), and D (>= 1.1.0),
+	// and by the main module (>= 1.3.1 with a different build).
+	reqs := mustParseAll(t, "1.2.0", "1.3.1+a", "1.1.0", "1.3.1+b", "1.3.1-rc1")
+	if got := semver.SelectMVS(reqs); got.String() != "1.3.1+a" {
+		t.Errorf("SelectMVS(%v): got %v, want 1.3.1+a", reqs, got)
+	}
+	if got := semver.SelectMVS(reqs[2:3]); got.String() != "1.1.0" {
+		t.Errorf("SelectMVS(%v): got %v, want 1.1.0", reqs[2:3], got)
+	}
+}
+
+func TestPredecessor(t *testing.T) {
+	catalog := mustParseAll(t,
+		"1.3.0", "1.0.0", "1.2.5", "2.0.0-rc1", "1.2.5+b", "1.3.0-rc2", "2.0.0",
+	)
+	tests := []struct {
+		input string
+		want  string // "" means none
+	}{
+		{"1.0.0", ""},
+		{"0.1.0", ""},
+		{"1.0.1", "1.0.0"},
+		{"1.3.0", "1.3.0-rc2"},
+		{"1.3.0-rc2", "1.2.5"}, // crosses a minor boundary
+		{"1.3.0-rc1", "1.2.5"},
+		{"2.0.0-rc1", "1.3.0"},
+		{"2.0.0", "2.0.0-rc1"},
+		{"9.0.0", "2.0.0"},
+	}
+	for _, tc := range tests {
+		v := mustParse(t, tc.input)
+		got, ok := semver.Predecessor(v, catalog)
+		if ok != (tc.want != "") || (ok && got.String() != tc.want) {
+			t.Errorf("Predecessor(%v): got (%v, %v), want %q", v, got, ok, tc.want)
+		}
+	}
+}
+
+func TestMergeAll(t *testing.T) {
+	checkVersions(t, "MergeAll()", semver.MergeAll())
+	checkVersions(t, "MergeAll(nil, nil)", semver.MergeAll(nil, nil))
+
+	a := mustParseAll(t, "1.0.0", "1.1.0", "1.2.0+a", "2.0.0")
+	b := mustParseAll(t, "0.9.0", "1.1.0+b", "1.2.0+b", "1.3.0")
+	c := mustParseAll(t, "1.2.0-rc1", "1.2.0-rc2", "1.2.0+c", "2.0.0-beta", "3.0.0")
+
+	checkVersions(t, "MergeAll(a)", semver.MergeAll(a), "1.0.0", "1.1.0", "1.2.0+a", "2.0.0")
+	checkVersions(t, "MergeAll(a, b, c)", semver.MergeAll(a, b, c),
+		"0.9.0", "1.0.0", "1.1.0", "1.2.0-rc1", "1.2.0-rc2", "1.2.0+a",
+		"1.3.0", "2.0.0-beta", "2.0.0", "3.0.0",
+	)
+	checkVersions(t, "MergeAll(c, nil, b, a)", semver.MergeAll(c, nil, b, a),
+		"0.9.0", "1.0.0", "1.1.0+b", "1.2.0-rc1", "1.2.0-rc2", "1.2.0+c",
+		"1.3.0", "2.0.0-beta", "2.0.0", "3.0.0",
+	)
+
+	// Equivalent elements within a single stream are also collapsed.
+	d := mustParseAll(t, "1.0.0+x", "1.0.0+y", "1.0.1")
+	checkVersions(t, "MergeAll(d)", semver.MergeAll(d), "1.0.0+x", "1.0.1")
+}
+
+func TestRank(t *testing.T) {
+	catalog := mustParseAll(t,
+		"1.0.0", "2.0.0", "1.1.0", "2.0.0-rc1", "1.1.0+b", "0.9.0", "2.0.0",
+	)
+	tests := []struct {
+		input string
+		want  int
+		ok    bool
+	}{
+		{"2.0.0", 0, true},
+		{"2.0.0+x", 0, true},
+		{"2.0.0-rc1", 1, true},
+		{"1.1.0", 2, true},
+		{"1.0.0", 3, true},
+		{"0.9.0", 4, true},
+		{"1.5.0", -1, false},
+		{"3.0.0", -1, false},
+	}
+	for _, tc := range tests {
+		v := mustParse(t, tc.input)
+		got, ok := semver.Rank(v, catalog)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("Rank(%v): got (%d, %v), want (%d, %v)", v, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	if got := semver.FindDuplicates(mustParseAll(t, "1.0.0", "1.0.1", "1.0.0-rc1", "2.0.0")); got != nil {
+		t.Errorf("FindDuplicates(distinct): got %v, want nil", got)
+	}
+	if got := semver.FindDuplicates(nil); got != nil {
+		t.Errorf("FindDuplicates(nil): got %v, want nil", got)
+	}
+
+	vs := mustParseAll(t,
+		"1.2.3+b", "2.0.0", "1.2.3", "1.0.0-rc1", "2.0.0+x", "1.2.3+a", "3.0.0", "1.0.0-rc1",
+	)
+	got := semver.FindDuplicates(vs)
+	want := [][]string{
+		{"1.2.3+b", "1.2.3", "1.2.3+a"},
+		{"2.0.0", "2.0.0+x"},
+		{"1.0.0-rc1", "1.0.0-rc1"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("FindDuplicates: got %d groups %v, want %d", len(got), got, len(want))
+	}
+	for i, g := range got {
+		checkVersions(t, "group", g, want[i]...)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (C) 2026 Michael J. Fromberger. All Rights Reserved.
+
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ParseError is the concrete type of errors reported by [Conform] and
+// [ParseConstraint]. Errors reported by [Parse] can also be converted to a
+// *ParseError using [errors.As].
+type ParseError struct {
+	Field  string // the field containing the error ("major", "release", etc.)
+	Offset int    // the byte offset in the input of the error
+	Err    error  // the underlying error
+}
+
+// Error satisfies the error interface.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid %s at offset %d: %v", e.Field, e.Offset, e.Err)
+}
+
+// Unwrap supports error wrapping.
+func (e *ParseError) Unwrap() error { return e.Err }
+
+var (
+	errTruncated  = errors.New("unexpected end of input")
+	errMissing    = errors.New("missing field")
+	errVPrefix    = errors.New("unexpected v prefix")
+	errWhitespace = errors.New("unexpected whitespace")
+)
+
+// Conform checks whether s conforms to the [semver 2.0.0 grammar]. If so,
+// it returns nil; otherwise it returns a [*ParseError] reporting the byte
+// offset in s of the first violation.
+//
+// Conform accepts the same strings as [Parse], but does not construct a [V],
+// and reports the locations of errors more precisely.
+//
+// [semver 2.0.0 grammar]: https://semver.org/#backusnaur-form-grammar-for-valid-semver-versions
+func Conform(s string) error {
+	fail := func(field string, pos int, err error) error {
+		if pos == len(s) && err != ErrEmptyWord {
+			err = errTruncated
+		}
+		return &ParseError{Field: field, Offset: pos, Err: err}
+	}
+
+	// Core: major "." minor "." patch, each a numeric identifier.
+	pos := 0
+	for i, field := range coreLabels {
+		start := pos
+		for pos < len(s) && isDigit(s[pos]) {
+			pos++
+		}
+		if pos == start {
+			return fail(field, pos, ErrNotNumber)
+		} else if s[start] == '0' && pos-start > 1 {
+			return fail(field, start, ErrLeadingZero)
+		}
+		if i < 2 {
+			if pos == len(s) || s[pos] != '.' {
+				return fail(field, pos, ErrInvalidChar)
+			}
+			pos++
+		}
+	}
+	if pos == len(s) {
+		return nil
+	}
+	field := "patch"
+
+	// Release: dot-separated identifiers, numeric ones without leading zeroes.
+	if s[pos] == '-' {
+		pos++
+		field = "release"
+		for {
+			start, digits := pos, true
+			for pos < len(s) && isWord(s[pos:pos+1]) {
+				digits = digits && isDigit(s[pos])
+				pos++
+			}
+			if pos == start {
+				return fail("release", pos, ErrEmptyWord)
+			} else if digits && s[start] == '0' && pos-start > 1 {
+				return fail("release", start, ErrLeadingZero)
+			}
+			if pos == len(s) {
+				return nil
+			} else if s[pos] != '.' {
+				break
+			}
+			pos++
+		}
+	}
+
+	// Build: dot-separated identifiers, leading zeroes permitted.
+	if s[pos] != '+' {
+		return fail(field, pos, ErrInvalidChar)
+	}
+	pos++
+	for {
+		start := pos
+		for pos < len(s) && isWord(s[pos:pos+1]) {
+			pos++
+		}
+		if pos == start {
+			return fail("build", pos, ErrEmptyWord)
+		} else if pos == len(s) {
+			return nil
+		} else if s[pos] != '.' {
+			return fail("build", pos, ErrInvalidChar)
+		}
+		pos++
+	}
+}
+
+// ParseStrict returns the [V] represented by s, as [Parse] does. It accepts
+// exactly the same strings as Parse, and in particular does NOT permit:
+//
+//   - a "v" prefix, as in "v1.2.3" (use [ParseClean] or [FromModule]),
+//   - leading or trailing whitespace, as in " 1.2.3\n", or
+//   - a partial version, as in "1.2" (use [ParseClean]).
+//
+// ParseStrict differs from Parse only in its errors: on failure, it reports a
+// [*ParseError] giving the offset of the first problem in s, as [Conform]
+// does, and specifically identifies the common mistakes listed above. This is
+// useful for explaining to a user why an input was rejected.
+func ParseStrict(s string) (V, error) {
+	v, err := Parse(s)
+	if err == nil {
+		return v, nil
+	}
+	var perr *ParseError
+	if !errors.As(Conform(s), &perr) {
+		return V{}, err // should not happen; Parse and Conform agree
+	}
+	if perr.Offset < len(s) {
+		switch c := s[perr.Offset]; {
+		case perr.Offset == 0 && (c == 'v' || c == 'V'):
+			perr.Err = errVPrefix
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			perr.Err = errWhitespace
+		}
+	} else if perr.Offset > 0 && isDigit(s[perr.Offset-1]) && (perr.Field == "major" || perr.Field == "minor") {
+		// The input ends after a complete major or minor version.
+		perr.Field = coreLabels[slices.Index(coreLabels[:], perr.Field)+1]
+		perr.Err = errMissing
+	}
+	return V{}, perr
+}
+
+func isDigit(b byte) bool { return b >= '0' && b <= '9' }
@@ -0,0 +1,174 @@
+// Copyright (C) 2026 Michael J. Fromberger. All Rights Reserved.
+
+package semver_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/creachadair/semver"
+)
+
+func TestConform(t *testing.T) {
+	// Valid and invalid examples from https://regex101.com/r/Ly7O1x/3/,
+	// linked from https://semver.org.
+	valid := []string{
+		"0.0.4",
+		"1.2.3",
+		"10.20.30",
+		"1.1.2-prerelease+meta",
+		"1.1.2+meta",
+		"1.1.2+meta-valid",
+		"1.0.0-alpha",
+		"1.0.0-beta",
+		"1.0.0-alpha.beta",
+		"1.0.0-alpha.beta.1",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha0.valid",
+		"1.0.0-alpha.0valid",
+		"1.0.0-alpha-a.b-c-somethinglong+build.1-aef.1-its-okay",
+		"1.0.0-rc.1+build.1",
+		"2.0.0-rc.1+build.123",
+		"1.2.3-beta",
+		"10.2.3-DEV-SNAPSHOT",
+		"1.2.3-SNAPSHOT-123",
+		"1.0.0",
+		"2.0.0",
+		"1.1.7",
+		"2.0.0+build.1848",
+		"2.0.1-alpha.1227",
+		"1.0.0-alpha+beta",
+		"1.2.3----RC-SNAPSHOT.12.9.1--.12+788",
+		"1.2.3----R-S.12.9.1--.12+meta",
+		"1.2.3----RC-SNAPSHOT.12.9.1--.12",
+		"1.0.0+0.build.1-rc.10000aaa-kk-0.1",
+		"99999999999999999999999.999999999999999999.99999999999999999",
+		"1.0.0-0A.is.legal",
+	}
+	for _, s := range valid {
+		if err := semver.Conform(s); err != nil {
+			t.Errorf("Conform(%q): unexpected error: %v", s, err)
+		}
+	}
+
+	invalid := []struct {
+		input  string
+		field  string
+		offset int
+	}{
+		{"1", "major", 1},
+		{"1.2", "minor", 3},
+		{"1.2.3-0123", "release", 6},
+		{"1.2.3-0123.0123", "release", 6},
+		{"1.1.2+.123", "build", 6},
+		{"+invalid", "major", 0},
+		{"-invalid", "major", 0},
+		{"-invalid+invalid", "major", 0},
+		{"-invalid.01", "major", 0},
+		{"alpha", "major", 0},
+		{"alpha.beta", "major", 0},
+		{"alpha.beta.1", "major", 0},
+		{"alpha.1", "major", 0},
+		{"alpha+beta", "major", 0},
+		{"alpha_beta", "major", 0},
+		{"alpha.", "major", 0},
+		{"alpha..", "major", 0},
+		{"beta", "major", 0},
+		{"1.0.0-alpha_beta", "release", 11},
+		{"-alpha.", "major", 0},
+		{"1.0.0-alpha..", "release", 12},
+		{"1.0.0-alpha..1", "release", 12},
+		{"1.0.0-alpha...1", "release", 12},
+		{"1.0.0-alpha....1", "release", 12},
+		{"1.0.0-alpha.....1", "release", 12},
+		{"1.0.0-alpha......1", "release", 12},
+		{"1.0.0-alpha.......1", "release", 12},
+		{"01.1.1", "major", 0},
+		{"1.01.1", "minor", 2},
+		{"1.1.01", "patch", 4},
+		{"1.2.3.DEV", "patch", 5},
+		{"1.2-SNAPSHOT", "minor", 3},
+		{"1.2.31.2.3----RC-SNAPSHOT.12.09.1--..12+788", "patch", 6},
+		{"1.2-RC-SNAPSHOT", "minor", 3},
+		{"-1.0.3-gamma+b7718", "major", 0},
+		{"+justmeta", "major", 0},
+		{"9.8.7+meta+meta", "build", 10},
+		{"9.8.7-whatever+meta+meta", "build", 19},
+		{"99999999999999999999999.999999999999999999.99999999999999999----RC-SNAPSHOT.12.09.1--------------------------------..12", "release", 79},
+
+		// Additional cases.
+		{"", "major", 0},
+		{"v1.2.3", "major", 0},
+		{" 1.2.3", "major", 0},
+		{"1.2.3 ", "patch", 5},
+		{"1.2.3-", "release", 6},
+		{"1.2.3+", "build", 6},
+		{"1.2.3-a.", "release", 8},
+		{"1.2.3-a+", "build", 8},
+	}
+	for _, tc := range invalid {
+		err := semver.Conform(tc.input)
+		var perr *semver.ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("Conform(%q): got %v, want *ParseError", tc.input, err)
+			continue
+		}
+		if perr.Field != tc.field || perr.Offset != tc.offset {
+			t.Errorf("Conform(%q): got %s at %d, want %s at %d (%v)",
+				tc.input, perr.Field, perr.Offset, tc.field, tc.offset, err)
+		}
+	}
+
+	// Parse and Conform accept the same strings.
+	for _, tc := range invalid {
+		if semver.IsValid(tc.input) {
+			t.Errorf("IsValid(%q): got true, but Conform rejects it", tc.input)
+		}
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	for _, s := range []string{"0.0.0", "1.2.3", "1.2.3-rc.1+b.007"} {
+		v, err := semver.ParseStrict(s)
+		if err != nil {
+			t.Errorf("ParseStrict(%q): unexpected error: %v", s, err)
+		} else if w := semver.MustParse(s); v != w {
+			t.Errorf("ParseStrict(%q): got %v, want %v", s, v, w)
+		}
+	}
+
+	tests := []struct {
+		input  string
+		field  string
+		offset int
+		errStr string
+	}{
+		{"v1.2.3", "major", 0, "unexpected v prefix"},
+		{"V1.2.3", "major", 0, "unexpected v prefix"},
+		{" 1.2.3", "major", 0, "unexpected whitespace"},
+		{"1.2.3\n", "patch", 5, "unexpected whitespace"},
+		{"1.2.3-rc.1 ", "release", 10, "unexpected whitespace"},
+		{"1", "minor", 1, "missing field"},
+		{"1.2", "patch", 3, "missing field"},
+		{"1.", "minor", 2, "unexpected end of input"},
+		{"", "major", 0, "unexpected end of input"},
+		{"1.02.3", "minor", 2, "leading zeroes"},
+		{"1.2.3-a..b", "release", 8, "empty identifier"},
+		{"1.2.3+x@y", "build", 7, "invalid character"},
+	}
+	for _, tc := range tests {
+		v, err := semver.ParseStrict(tc.input)
+		var perr *semver.ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("ParseStrict(%q): got (%v, %v), want *ParseError", tc.input, v, err)
+			continue
+		}
+		if perr.Field != tc.field || perr.Offset != tc.offset || perr.Err.Error() != tc.errStr {
+			t.Errorf("ParseStrict(%q): got %s at %d (%v), want %s at %d (%s)",
+				tc.input, perr.Field, perr.Offset, perr.Err, tc.field, tc.offset, tc.errStr)
+		}
+		if _, err := semver.Parse(tc.input); err == nil {
+			t.Errorf("Parse(%q): unexpectedly succeeded", tc.input)
+		}
+	}
+}
@@ -0,0 +1,700 @@
+// Copyright (C) 2026 Michael J. Fromberger. All Rights Reserved.
+
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// A Constraint is a predicate on versions, such as ">=1.2.0 <2.0.0".
+// Use [ParseConstraint] to construct a Constraint, and [Constraint.Matches]
+// to check whether a version satisfies it.
+//
+// A constraint consists of one or more clauses separated by "||", and is
+// satisfied by a version that satisfies any of its clauses. Each clause is a
+// sequence of comparisons separated by whitespace, and is satisfied by a
+// version that satisfies all of its comparisons. A comparison consists of an
+// operator followed by a version:
+//
+//	=    equal to (the default if the operator is omitted)
+//	!=   not equal to
+//	<    before
+//	<=   before or equal to
+//	>    after
+//	>=   after or equal to
+//
+// Comparisons use [Compare], so build metadata are ignored.
+//
+// In addition, a caret range "^V" admits versions at or after V that do not
+// change the left-most non-zero core field of V. Missing minor and patch
+// versions in V are treated as 0:
+//
+//	^1.2.3  is  >=1.2.3 <2.0.0
+//	^0.2.3  is  >=0.2.3 <0.3.0
+//	^0.0.3  is  >=0.0.3 <0.0.4
+//	^1      is  >=1.0.0 <2.0.0
+//	^0.2    is  >=0.2.0 <0.3.0
+//	^0      is  >=0.0.0 <1.0.0
+//
+// A tilde range "~V" admits versions at or after V that do not change the
+// minor version of V, or the major version of V if only the major version is
+// given. Unlike a caret range, a tilde range does not depend on which fields
+// of V are zero:
+//
+//	~1.2.3  is  >=1.2.3 <1.3.0
+//	~0.0.3  is  >=0.0.3 <0.1.0
+//	~1.2    is  >=1.2.0 <1.3.0
+//	~1      is  >=1.0.0 <2.0.0
+//
+// An x-range is a version in which trailing core fields are replaced by one
+// of the wildcards "x", "X", or "*". It admits any value in the wildcard
+// positions. Once a wildcard appears, all the following core fields must also
+// be wildcards or omitted, and no release or build label is permitted:
+//
+//	1.2.x   is  >=1.2.0 <1.3.0
+//	1.x     is  >=1.0.0 <2.0.0
+//	1.*.*   is  >=1.0.0 <2.0.0
+//	*       matches any version
+//
+// An x-range may be used without an operator, with "=", or as the operand of
+// a caret or tilde range; it may not be used with other operators.
+//
+// A hyphen range "A - B" admits versions from A through B inclusive. The
+// hyphen must be separated from both endpoints by whitespace, to distinguish
+// it from a release label. Either endpoint may be partial, or an x-range;
+// missing or wildcard fields of A are treated as 0, and a partial B admits
+// every version that matches its specified fields:
+//
+//	1.2.3 - 2.3.4  is  >=1.2.3 <=2.3.4
+//	1.2 - 2.3.4    is  >=1.2.0 <=2.3.4
+//	1.2.3 - 2.3    is  >=1.2.3 <2.4.0
+//	1.2 - 2        is  >=1.2.0 <3.0.0
+//
+// Ranges are expanded into the equivalent comparisons when parsed.
+//
+// By default, a prerelease version satisfies a clause only if some comparison
+// in that clause is against a prerelease of the same core version. Thus
+// 1.2.3-rc1 does not satisfy ">=1.0.0" or "^1.2.0", though it precedes 2.0.0
+// and follows 1.0.0, but it does satisfy ">=1.2.3-beta <1.3.0". This rule,
+// which is also used by npm, prevents a range from admitting prereleases
+// unless it explicitly opts in to them. Use [Constraint.AllowPrereleases] to
+// disable this rule and compare prereleases like any other version.
+//
+// The zero Constraint has no clauses, and matches no versions.
+type Constraint struct {
+	alts []alt // disjunction
+}
+
+// An alt is one alternative of a constraint: a clause, together with the
+// prerelease gates that restrict it.
+type alt struct {
+	terms clause
+	gates []gate // a prerelease must pass every gate; nil if ungated
+}
+
+// A gate lists the core versions whose prereleases it admits. Each parsed
+// clause has one gate, listing the cores of its prerelease operands.
+type gate []V
+
+// A clause is a conjunction of comparisons.
+type clause []term
+
+// A term is a single comparison against a version.
+type term struct {
+	op op
+	v  V
+}
+
+type op int
+
+const (
+	opEQ op = iota
+	opNE
+	opLT
+	opLE
+	opGT
+	opGE
+)
+
+// opNames are the canonical spellings of the operators, indexed by op.
+var opNames = [...]string{"=", "!=", "<", "<=", ">", ">="}
+
+func (o op) String() string { return opNames[o] }
+
+// match reports whether v satisfies t.
+func (t term) match(v V) bool {
+	c := Compare(v, t.v)
+	switch t.op {
+	case opEQ:
+		return c == 0
+	case opNE:
+		return c != 0
+	case opLT:
+		return c < 0
+	case opLE:
+		return c <= 0
+	case opGT:
+		return c > 0
+	case opGE:
+		return c >= 0
+	}
+	panic("invalid operator")
+}
+
+func (t term) String() string { return t.op.String() + t.v.String() }
+
+// match reports whether v satisfies all the terms of c.
+func (c clause) match(v V) bool {
+	for _, t := range c {
+		if !t.match(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// gateOf returns the gate for a parsed clause c, which admits the prereleases
+// of the core versions of the prerelease operands of c.
+func gateOf(c clause) gate {
+	var g gate
+	for _, t := range c {
+		if t.v.release != "" {
+			g = append(g, t.v.Core())
+		}
+	}
+	return g
+}
+
+// match reports whether v satisfies the terms of a. A prerelease v must also
+// pass the gates of a.
+func (a alt) match(v V) bool {
+	if v.release != "" && !a.admitsCore(v.Core()) {
+		return false
+	}
+	return a.terms.match(v)
+}
+
+// admitsCore reports whether every gate of a admits the prereleases of core.
+func (a alt) admitsCore(core V) bool {
+	for _, g := range a.gates {
+		if !slices.ContainsFunc(g, sameAs(core)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c clause) String() string {
+	if len(c) == 0 {
+		return "*" // matches anything
+	}
+	ss := make([]string, len(c))
+	for i, t := range c {
+		ss[i] = t.String()
+	}
+	return strings.Join(ss, " ")
+}
+
+// Matches reports whether v satisfies c.
+func (c Constraint) Matches(v V) bool {
+	for _, a := range c.alts {
+		if a.match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowPrereleases returns a copy of c that compares prerelease versions
+// using the usual precedence order, without the additional restriction
+// described for [Constraint]. For example:
+//
+//	c := MustParseConstraint(">=1.0.0")
+//	v := MustParse("1.2.3-rc1")
+//	c.Matches(v)                    // false
+//	c.AllowPrereleases().Matches(v) // true
+func (c Constraint) AllowPrereleases() Constraint {
+	alts := make([]alt, len(c.alts))
+	for i, a := range c.alts {
+		alts[i] = alt{terms: a.terms}
+	}
+	return Constraint{alts: alts}
+}
+
+// String returns a normalized string representation of c, in which each
+// comparison has an explicit operator, for example ">=1.2.0 <2.0.0 || =3.0.0".
+// The result can be parsed by [ParseConstraint] to obtain a constraint
+// equivalent to c. The string does not record [Constraint.AllowPrereleases].
+func (c Constraint) String() string {
+	ss := make([]string, len(c.alts))
+	for i, a := range c.alts {
+		ss[i] = a.terms.String()
+	}
+	return strings.Join(ss, " || ")
+}
+
+// Intersect returns a constraint that matches exactly the versions matched by
+// both c and d. Each clause of the result combines one clause of c with one
+// clause of d, and is simplified to at most one lower bound, one upper bound,
+// and any "!=" comparisons that fall between them. For example, the
+// intersection of ">=1.0.0 <2.0.0" and ">=1.5.0" is ">=1.5.0 <2.0.0".
+//
+// Clauses that cannot be satisfied are retained in the result; use
+// [Constraint.IsSatisfiable] to check whether any version matches.
+//
+// The prerelease rule is applied as for the clauses being combined: a clause
+// of the result admits a prerelease only if both of its source clauses do,
+// each judged by its own operands, unless its constraint allows prereleases
+// (see [Constraint.AllowPrereleases]).
+func (c Constraint) Intersect(d Constraint) Constraint {
+	var out Constraint
+	for _, a := range c.alts {
+		for _, b := range d.alts {
+			out.alts = append(out.alts, alt{
+				terms: slices.Concat(a.terms, b.terms).simplify(),
+				gates: slices.Concat(a.gates, b.gates),
+			})
+		}
+	}
+	return out
+}
+
+// Union returns a constraint that matches exactly the versions matched by
+// either c or d. The clauses of the result are those of c followed by those
+// of d, and each admits prereleases exactly as it did in its source.
+func (c Constraint) Union(d Constraint) Constraint {
+	return Constraint{alts: slices.Concat(c.alts, d.alts)}
+}
+
+// IsSatisfiable reports whether there is any version that matches c.
+// For example, ">=2.0.0 <1.0.0" and ">1.0.0 <1.0.1-0" are not satisfiable,
+// nor is ">1.0.0 <1.0.1" unless c allows prereleases.
+// The zero Constraint is not satisfiable.
+func (c Constraint) IsSatisfiable() bool {
+	return slices.ContainsFunc(c.alts, alt.satisfiable)
+}
+
+// A bound is a lower or upper limit on the versions admitted by a clause.
+type bound struct {
+	v    V
+	incl bool // whether v itself is admitted
+	ok   bool // whether the bound is present
+}
+
+// admits reports whether b admits v. For a lower bound dir > 0, and for an
+// upper bound dir < 0.
+func (b bound) admits(v V, dir int) bool {
+	if !b.ok {
+		return true
+	}
+	c := Compare(v, b.v) * dir
+	return c > 0 || (c == 0 && b.incl)
+}
+
+// tighten returns the stricter of b and the bound at v, in direction dir as
+// for admits.
+func (b bound) tighten(v V, incl bool, dir int) bound {
+	if c := Compare(v, b.v) * dir; !b.ok || c > 0 || (c == 0 && !incl) {
+		return bound{v: v, incl: incl, ok: true}
+	}
+	return b
+}
+
+// bounds returns the tightest lower and upper bounds of c, and the operands of
+// its "!=" comparisons.
+func (c clause) bounds() (lo, hi bound, ne []V) {
+	for _, t := range c {
+		switch t.op {
+		case opEQ:
+			lo, hi = lo.tighten(t.v, true, 1), hi.tighten(t.v, true, -1)
+		case opNE:
+			ne = append(ne, t.v)
+		case opLT, opLE:
+			hi = hi.tighten(t.v, t.op == opLE, -1)
+		case opGT, opGE:
+			lo = lo.tighten(t.v, t.op == opGE, 1)
+		}
+	}
+	return
+}
+
+// simplify returns a clause equivalent to c, consisting of at most one lower
+// bound and one upper bound (or a single "=" if these coincide), followed by
+// the distinct "!=" comparisons of c that the bounds admit.
+func (c clause) simplify() clause {
+	lo, hi, ne := c.bounds()
+	var out clause
+	if lo.ok && hi.ok && lo.incl && hi.incl && Compare(lo.v, hi.v) == 0 {
+		out = append(out, term{op: opEQ, v: lo.v})
+	} else {
+		if lo.ok {
+			out = append(out, term{op: cond(lo.incl, opGE, opGT), v: lo.v})
+		}
+		if hi.ok {
+			out = append(out, term{op: cond(hi.incl, opLE, opLT), v: hi.v})
+		}
+	}
+	for i, v := range ne {
+		if !lo.admits(v, 1) || !hi.admits(v, -1) || slices.ContainsFunc(ne[:i], sameAs(v)) {
+			continue
+		}
+		out = append(out, term{op: opNE, v: v})
+	}
+	return out
+}
+
+// satisfiable reports whether any version satisfies a, including its gates.
+func (a alt) satisfiable() bool {
+	lo, hi, ne := a.terms.bounds()
+
+	// Find the least version admitted by the lower bound and not excluded by a
+	// "!=", and check whether the upper bound admits it.
+	if a.gates == nil {
+		x := least(V{major: "0", minor: "0", patch: "0", release: "0"}, lo, ne) // 0.0.0-0 is the least of all versions
+		return hi.admits(x, -1)
+	}
+
+	// When gated, first check for a stable version...
+	x := V{major: "0", minor: "0", patch: "0"}
+	if lo.ok {
+		if x = lo.v.Core(); !lo.admits(x, 1) {
+			x = x.IncPatch()
+		}
+	}
+	for slices.ContainsFunc(ne, sameAs(x)) {
+		x = x.IncPatch()
+	}
+	if hi.admits(x, -1) {
+		return true
+	}
+
+	// ...then for a prerelease of each core version that the gates admit.
+	for _, core := range a.gates[0] {
+		if !a.admitsCore(core) {
+			continue
+		}
+		x := least(core.WithRelease("0"), lo, ne)
+		if x.release != "" && Compare(x.Core(), core) == 0 && hi.admits(x, -1) {
+			return true
+		}
+	}
+	return false
+}
+
+// least returns the least version at or after x that is admitted by lo and
+// not excluded by ne.
+func least(x V, lo bound, ne []V) V {
+	if !lo.admits(x, 1) {
+		x = lo.v
+		if !lo.incl {
+			x = successor(x)
+		}
+	}
+	for slices.ContainsFunc(ne, sameAs(x)) {
+		x = successor(x)
+	}
+	return x
+}
+
+// successor returns the least version that follows v in precedence order.
+// This is v with a release identifier "0" added, if v is a prerelease, or the
+// prerelease "0" of the next patch version otherwise.
+func successor(v V) V {
+	if v.release != "" {
+		return V{major: v.major, minor: v.minor, patch: v.patch, release: v.release + ".0"}
+	}
+	next := v.IncPatch()
+	next.release = "0"
+	return next
+}
+
+// sameAs returns a function that reports whether its argument has the same
+// precedence as v.
+func sameAs(v V) func(V) bool { return func(w V) bool { return Compare(v, w) == 0 } }
+
+func cond[T any](ok bool, x, y T) T {
+	if ok {
+		return x
+	}
+	return y
+}
+
+var (
+	errEmptyClause = errors.New("empty clause")
+	errMissingVers = errors.New("missing version")
+	errUnknownOp   = errors.New("unknown operator")
+	errWildcardOp  = errors.New("wildcard not permitted with operator")
+	errWildcardPos = errors.New("value follows wildcard")
+	errWildcardTag = errors.New("wildcard not permitted with release or build")
+	errHyphen      = errors.New("hyphen range without lower bound")
+	errHyphenOp    = errors.New("operator not permitted in hyphen range")
+)
+
+// ParseConstraint parses s as a [Constraint]. If s is not a valid constraint,
+// ParseConstraint returns an error of concrete type [*ParseError], giving the
+// byte offset of the problem in s.
+func ParseConstraint(s string) (Constraint, error) {
+	var out Constraint
+	pos := 0
+	for {
+		text, rest, more := strings.Cut(s[pos:], "||")
+		terms, err := parseClause(text, pos)
+		if err != nil {
+			return Constraint{}, err
+		}
+		out.alts = append(out.alts, alt{terms: terms, gates: []gate{gateOf(terms)}})
+		if !more {
+			return out, nil
+		}
+		pos = len(s) - len(rest)
+	}
+}
+
+// MustParseConstraint returns the [Constraint] represented by s, or panics.
+// This is intended for use in program initialization; use [ParseConstraint]
+// to check for errors.
+func MustParseConstraint(s string) Constraint {
+	c, err := ParseConstraint(s)
+	if err != nil {
+		panic(fmt.Sprintf("ParseConstraint %q: %v", s, err))
+	}
+	return c
+}
+
+// IsValidConstraint reports whether s is a valid constraint string.
+func IsValidConstraint(s string) bool { _, err := ParseConstraint(s); return err == nil }
+
+// parseClause parses a single clause of a constraint. The text begins at
+// byte offset base of the original input, for error reporting.
+func parseClause(text string, base int) (clause, error) {
+	var out clause
+	toks := fields(text, base)
+	if len(toks) == 0 {
+		return nil, &ParseError{Field: "constraint", Offset: base + len(text), Err: errEmptyClause}
+	}
+	for i := 0; i < len(toks); i++ {
+		if i+1 < len(toks) && toks[i+1].text == "-" {
+			if i+2 == len(toks) {
+				return nil, &ParseError{Field: "constraint", Offset: toks[i+1].pos + 1, Err: errMissingVers}
+			}
+			t, err := parseHyphen(toks[i], toks[i+2])
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, t...)
+			i += 2
+			continue
+		} else if toks[i].text == "-" {
+			return nil, &ParseError{Field: "constraint", Offset: toks[i].pos, Err: errHyphen}
+		}
+		opTok := toks[i]
+		vtext := strings.TrimLeft(opTok.text, "=!<>^~")
+		opTok.text = opTok.text[:len(opTok.text)-len(vtext)]
+		vtok := token{text: vtext, pos: opTok.pos + len(opTok.text)}
+		if vtext == "" {
+			// The operator is separated from its version by whitespace.
+			if i+1 == len(toks) {
+				return nil, &ParseError{Field: "constraint", Offset: vtok.pos, Err: errMissingVers}
+			}
+			i++
+			vtok = toks[i]
+		}
+		t, err := parseTerm(opTok, vtok)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t...)
+	}
+	return out, nil
+}
+
+// parseTerm parses a single comparison with the given operator and version.
+// If the operator is empty, it defaults to "=".
+func parseTerm(opTok, vtok token) ([]term, error) {
+	if opTok.text == "^" || opTok.text == "~" {
+		v, n, _, err := parseXRange(vtok.text)
+		if err != nil {
+			return nil, &ParseError{Field: "constraint", Offset: vtok.pos, Err: err}
+		}
+		if n == 0 {
+			return nil, nil // matches anything
+		} else if opTok.text == "~" {
+			return expandTilde(v, n), nil
+		}
+		return expandCaret(v, n), nil
+	}
+	o := opEQ
+	if opTok.text != "" {
+		o = op(-1)
+		for i, name := range opNames {
+			if opTok.text == name {
+				o = op(i)
+				break
+			}
+		}
+		if o < 0 {
+			return nil, &ParseError{Field: "constraint", Offset: opTok.pos, Err: errUnknownOp}
+		}
+	}
+	if v, n, wild, err := parseXRange(vtok.text); wild {
+		if err != nil {
+			return nil, &ParseError{Field: "constraint", Offset: vtok.pos, Err: err}
+		} else if o != opEQ {
+			return nil, &ParseError{Field: "constraint", Offset: opTok.pos, Err: errWildcardOp}
+		} else if n == 0 {
+			return nil, nil // matches anything
+		}
+		return expandTilde(v, n), nil // n < 3, so this is equivalent
+	}
+	v, err := Parse(vtok.text)
+	if err != nil {
+		return nil, &ParseError{Field: "constraint", Offset: vtok.pos, Err: err}
+	}
+	return []term{{op: o, v: v}}, nil
+}
+
+// parseHyphen parses a hyphen range with the given endpoints.
+func parseHyphen(lo, hi token) ([]term, error) {
+	var vs [2]V
+	var ns [2]int
+	for i, tok := range []token{lo, hi} {
+		if strings.TrimLeft(tok.text, "=!<>^~") != tok.text {
+			return nil, &ParseError{Field: "constraint", Offset: tok.pos, Err: errHyphenOp}
+		}
+		v, n, _, err := parseXRange(tok.text)
+		if err != nil {
+			return nil, &ParseError{Field: "constraint", Offset: tok.pos, Err: err}
+		}
+		vs[i], ns[i] = v, n
+	}
+	var out []term
+	if ns[0] > 0 {
+		out = append(out, term{op: opGE, v: vs[0]})
+	}
+	switch ns[1] {
+	case 1:
+		out = append(out, term{op: opLT, v: vs[1].IncMajor()})
+	case 2:
+		out = append(out, term{op: opLT, v: vs[1].IncMinor()})
+	case 3:
+		out = append(out, term{op: opLE, v: vs[1]})
+	}
+	return out, nil
+}
+
+// expandCaret returns the comparisons for a caret range on v, in which the
+// first n core fields are specified.
+func expandCaret(v V, n int) []term {
+	hi := v.IncMajor()
+	if v.Major() == 0 && n > 1 {
+		if v.Minor() != 0 || n == 2 {
+			hi = v.IncMinor()
+		} else {
+			hi = v.IncPatch()
+		}
+	}
+	return bounded(v, hi)
+}
+
+// expandTilde returns the comparisons for a tilde range on v, in which the
+// first n core fields are specified.
+func expandTilde(v V, n int) []term {
+	if n == 1 {
+		return bounded(v, v.IncMajor())
+	}
+	return bounded(v, v.IncMinor())
+}
+
+// bounded returns the comparisons for the half-open interval [lo, hi).
+func bounded(lo, hi V) []term { return []term{{op: opGE, v: lo}, {op: opLT, v: hi}} }
+
+// parseXRange parses s as a partial version, as [parsePartial] does, but also
+// permits trailing core fields to be wildcards. It reports whether s contained
+// any wildcards. The core fields preceding the first wildcard are counted as
+// specified, so for "*" n == 0.
+func parseXRange(s string) (_ V, n int, wild bool, _ error) {
+	core, _, _, hasRelease, hasBuild := splitReleaseBuild(s)
+	ws := strings.Split(core, ".")
+	k := slices.IndexFunc(ws, isWildcard)
+	if k < 0 {
+		v, n, err := parsePartial(s)
+		return v, n, false, err
+	} else if hasRelease || hasBuild {
+		return V{}, 0, true, errWildcardTag
+	} else if len(ws) > 3 {
+		return V{}, 0, true, countError(len(ws))
+	}
+	for i, w := range ws[k:] {
+		if !isWildcard(w) {
+			return V{}, 0, true, invalidThingError{coreLabels[k+i], w, errWildcardPos}
+		}
+	}
+	if k == 0 {
+		return V{}, 0, true, nil
+	}
+	v, n, err := parsePartial(strings.Join(ws[:k], "."))
+	return v, n, true, err
+}
+
+func isWildcard(s string) bool { return s == "x" || s == "X" || s == "*" }
+
+// parsePartial parses s as a version in which the minor and patch versions
+// may be omitted, as in "1" or "1.2". It returns the parsed version, with
+// omitted fields set to 0, and the number of core fields specified.
+// A release label or build metadata are permitted only if all three core
+// fields are specified.
+func parsePartial(s string) (V, int, error) {
+	v, perr := Parse(s)
+	if perr == nil {
+		return v, 3, nil
+	}
+	core, _, _, hasRelease, hasBuild := splitReleaseBuild(s)
+	ps, err := split3(core)
+	n := 0
+	for n < len(ps) && ps[n] != "" {
+		n++
+	}
+	if n == 0 || n == 3 || hasRelease || hasBuild || err != countError(n) {
+		return V{}, 0, perr // not a partial version; report the original error
+	}
+	v = V{major: ps[0], minor: "0", patch: "0"}
+	for i, p := range ps[:n] {
+		if err := checkVNum(p); err != nil {
+			return V{}, 0, invalidThingError{coreLabels[i], p, err}
+		}
+	}
+	if n == 2 {
+		v.minor = ps[1]
+	}
+	return v, n, nil
+}
+
+// A token is a whitespace-delimited field of a constraint.
+type token struct {
+	text string
+	pos  int // byte offset in the original input
+}
+
+// fields splits s into whitespace-separated tokens, recording the offset of
+// each relative to base.
+func fields(s string, base int) []token {
+	var out []token
+	for i := 0; i < len(s); {
+		if isSpace(s[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(s) && !isSpace(s[j]) {
+			j++
+		}
+		out = append(out, token{text: s[i:j], pos: base + i})
+		i = j
+	}
+	return out
+}
+
+func isSpace(b byte) bool { return b == ' ' || b == '\t' || b == '\n' || b == '\r' }
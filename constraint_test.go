@@ -0,0 +1,462 @@
+// Copyright (C) 2026 Michael J. Fromberger. All Rights Reserved.
+
+package semver_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/creachadair/mds/mtest"
+	"github.com/creachadair/semver"
+)
+
+func TestConstraint(t *testing.T) {
+	tests := []struct {
+		input string
+		str   string   // normalized string
+		yes   []string // versions that match
+		no    []string // versions that do not match
+	}{
+		{"1.2.3", "=1.2.3",
+			[]string{"1.2.3", "1.2.3+build"},
+			[]string{"1.2.4", "1.2.3-rc1", "1.2.2"}},
+		{"=1.2.3", "=1.2.3", []string{"1.2.3"}, []string{"1.2.4"}},
+		{"!=1.2.3", "!=1.2.3",
+			[]string{"1.2.4", "0.0.0"},
+			[]string{"1.2.3", "1.2.3+x", "1.2.3-rc1"}},
+		{"<1.2.3", "<1.2.3",
+			[]string{"1.2.2", "0.1.0"},
+			[]string{"1.2.3", "1.2.4", "1.2.3-rc1"}},
+		{"<=1.2.3", "<=1.2.3",
+			[]string{"1.2.2", "1.2.3", "1.2.3+b"},
+			[]string{"1.2.4", "2.0.0-rc1"}},
+		{">1.2.3", ">1.2.3",
+			[]string{"1.2.4", "2.0.0"},
+			[]string{"1.2.3", "1.2.3+x", "1.2.3-rc1", "1.2.4-rc1"}},
+		{">=1.2.3", ">=1.2.3",
+			[]string{"1.2.3", "1.2.4", "9.0.0"},
+			[]string{"1.2.2", "1.2.3-rc1"}},
+		{">=1.2.0 <2.0.0", ">=1.2.0 <2.0.0",
+			[]string{"1.2.0", "1.9.9"},
+			[]string{"1.1.9", "2.0.0", "2.0.1", "1.5.0-rc1", "2.0.0-rc1"}},
+		{"  >=  1.2.0\t<2.0.0 ", ">=1.2.0 <2.0.0",
+			[]string{"1.2.0"}, []string{"2.0.0"}},
+		{">=1.2.0 <2.0.0 || 3.0.0 || >4.0.0", ">=1.2.0 <2.0.0 || =3.0.0 || >4.0.0",
+			[]string{"1.2.0", "3.0.0", "4.0.1"},
+			[]string{"2.0.0", "3.0.1", "4.0.0"}},
+		{">=1.0.0 !=1.5.0 <2.0.0", ">=1.0.0 !=1.5.0 <2.0.0",
+			[]string{"1.0.0", "1.4.9", "1.5.1"},
+			[]string{"1.5.0", "0.9.0", "2.0.0"}},
+		{">=1.0.0-alpha.2 <1.0.0", ">=1.0.0-alpha.2 <1.0.0",
+			[]string{"1.0.0-alpha.2", "1.0.0-alpha.10", "1.0.0-beta"},
+			[]string{"1.0.0-alpha.1", "1.0.0", "1.0.0-alpha"}},
+
+		// Caret ranges.
+		{"^1.2.3", ">=1.2.3 <2.0.0",
+			[]string{"1.2.3", "1.9.0", "1.2.4"},
+			[]string{"1.2.2", "2.0.0", "1.2.3-rc1"}},
+		{"^0.2.3", ">=0.2.3 <0.3.0",
+			[]string{"0.2.3", "0.2.9"},
+			[]string{"0.3.0", "0.2.2", "1.0.0"}},
+		{"^0.0.3", ">=0.0.3 <0.0.4",
+			[]string{"0.0.3"},
+			[]string{"0.0.4", "0.0.2", "0.0.4-rc1"}},
+		{"^0.0.0", ">=0.0.0 <0.0.1", []string{"0.0.0"}, []string{"0.0.1", "0.1.0"}},
+		{"^1", ">=1.0.0 <2.0.0", []string{"1.0.0", "1.99.0"}, []string{"0.9.0", "2.0.0"}},
+		{"^0.2", ">=0.2.0 <0.3.0", []string{"0.2.0", "0.2.7"}, []string{"0.3.0"}},
+		{"^0.0", ">=0.0.0 <0.1.0", []string{"0.0.0", "0.0.9"}, []string{"0.1.0"}},
+		{"^0", ">=0.0.0 <1.0.0", []string{"0.0.0", "0.9.9"}, []string{"1.0.0"}},
+		{"^1.2.3-rc1", ">=1.2.3-rc1 <2.0.0", []string{"1.2.3-rc2", "1.2.3"}, []string{"1.2.3-rc0"}},
+		{"^ 1.2.3 || ^3", ">=1.2.3 <2.0.0 || >=3.0.0 <4.0.0",
+			[]string{"1.5.0", "3.1.0"}, []string{"2.0.0", "4.0.0"}},
+
+		// Tilde ranges.
+		{"~1.2.3", ">=1.2.3 <1.3.0",
+			[]string{"1.2.3", "1.2.9"},
+			[]string{"1.2.2", "1.3.0", "2.0.0"}},
+		{"~0.0.3", ">=0.0.3 <0.1.0", []string{"0.0.3", "0.0.9"}, []string{"0.1.0"}},
+		{"~1.2", ">=1.2.0 <1.3.0", []string{"1.2.0", "1.2.5"}, []string{"1.3.0", "1.1.9"}},
+		{"~1", ">=1.0.0 <2.0.0", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"}},
+		{"~0", ">=0.0.0 <1.0.0", []string{"0.5.0"}, []string{"1.0.0"}},
+		{"~1.2.3 || ^2.0.0", ">=1.2.3 <1.3.0 || >=2.0.0 <3.0.0",
+			[]string{"1.2.4", "2.5.0"}, []string{"1.3.0", "3.0.0"}},
+
+		// X-ranges.
+		{"1.2.x", ">=1.2.0 <1.3.0", []string{"1.2.0", "1.2.9"}, []string{"1.1.9", "1.3.0"}},
+		{"1.x", ">=1.0.0 <2.0.0", []string{"1.0.0", "1.5.2"}, []string{"0.9.0", "2.0.0"}},
+		{"=1.X.*", ">=1.0.0 <2.0.0", []string{"1.0.0"}, []string{"2.0.0"}},
+		{"1.*", ">=1.0.0 <2.0.0", []string{"1.9.9"}, []string{"2.0.0"}},
+		{"*", "*", []string{"0.0.0", "1.2.3"}, []string{"1.0.0-rc1"}},
+		{"x.x.x", "*", []string{"0.0.0", "99.0.0"}, nil},
+		{"* >=1.0.0", ">=1.0.0", []string{"1.0.0"}, []string{"0.9.0"}},
+		{"1.x || *", ">=1.0.0 <2.0.0 || *", []string{"1.0.0", "5.0.0"}, nil},
+		{"^1.x", ">=1.0.0 <2.0.0", []string{"1.0.0", "1.5.0"}, []string{"2.0.0"}},
+		{"^0.1.x", ">=0.1.0 <0.2.0", []string{"0.1.5"}, []string{"0.2.0"}},
+		{"~1.2.x", ">=1.2.0 <1.3.0", []string{"1.2.5"}, []string{"1.3.0"}},
+		{"^*", "*", []string{"3.0.0"}, nil},
+
+		// Hyphen ranges.
+		{"1.2.3 - 2.3.4", ">=1.2.3 <=2.3.4",
+			[]string{"1.2.3", "2.0.0", "2.3.4", "2.3.4+b"},
+			[]string{"1.2.2", "2.3.5", "2.4.0"}},
+		{"1.2 - 2.3.4", ">=1.2.0 <=2.3.4", []string{"1.2.0"}, []string{"1.1.9"}},
+		{"1.2.3 - 2.3", ">=1.2.3 <2.4.0", []string{"2.3.9"}, []string{"2.4.0"}},
+		{"1.2 - 2", ">=1.2.0 <3.0.0", []string{"1.2.0", "2.9.9"}, []string{"1.1.0", "3.0.0"}},
+		{"1.x - 2.x", ">=1.0.0 <3.0.0", []string{"1.0.0", "2.5.0"}, []string{"3.0.0"}},
+		{"* - 2.0.0", "<=2.0.0", []string{"0.0.0", "2.0.0"}, []string{"2.0.1"}},
+		{"1.0.0 - *", ">=1.0.0", []string{"1.0.0", "9.0.0"}, []string{"0.9.0"}},
+		{"1.0.0-rc1 - 1.0.0-rc3", ">=1.0.0-rc1 <=1.0.0-rc3",
+			[]string{"1.0.0-rc2"}, []string{"1.0.0", "1.0.0-rc4"}},
+		{"1.0.0 - 1.5.0 !=1.2.0 || 3.0.0", ">=1.0.0 <=1.5.0 !=1.2.0 || =3.0.0",
+			[]string{"1.1.0", "3.0.0"}, []string{"1.2.0", "2.0.0"}},
+	}
+	for _, tc := range tests {
+		c, err := semver.ParseConstraint(tc.input)
+		if err != nil {
+			t.Errorf("ParseConstraint(%q): unexpected error: %v", tc.input, err)
+			continue
+		}
+		if got := c.String(); got != tc.str {
+			t.Errorf("ParseConstraint(%q): got %q, want %q", tc.input, got, tc.str)
+		}
+		for _, s := range tc.yes {
+			if v := mustParse(t, s); !c.Matches(v) {
+				t.Errorf("Constraint %q: Matches(%v) = false, want true", c, v)
+			}
+		}
+		for _, s := range tc.no {
+			if v := mustParse(t, s); c.Matches(v) {
+				t.Errorf("Constraint %q: Matches(%v) = true, want false", c, v)
+			}
+		}
+
+		// The normalized string should parse to an equivalent constraint.
+		d, err := semver.ParseConstraint(c.String())
+		if err != nil {
+			t.Errorf("ParseConstraint(%q): unexpected error: %v", c.String(), err)
+		} else if d.String() != c.String() {
+			t.Errorf("ParseConstraint(%q): got %q, want %q", c.String(), d, c)
+		}
+	}
+
+	var zero semver.Constraint
+	if zero.Matches(semver.V{}) {
+		t.Error("Zero constraint matches 0.0.0")
+	}
+}
+
+func TestConstraintErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int
+		errStr string
+	}{
+		{"", 0, "empty clause"},
+		{"   ", 3, "empty clause"},
+		{"1.2.3 ||", 8, "empty clause"},
+		{"|| 1.2.3", 0, "empty clause"},
+		{"1.0.0 || || 2.0.0", 9, "empty clause"},
+		{"=>1.2.3", 0, "unknown operator"},
+		{"1.0.0 !1.2.3", 6, "unknown operator"},
+		{"<<1.2.3", 0, "unknown operator"},
+		{">=", 2, "missing version"},
+		{"1.0.0 <", 7, "missing version"},
+		{">=1.2", 2, "wrong length"},
+		{"1.0.0 || >=1.2.3-", 11, "empty release"},
+		{">= bogus", 3, "wrong length"},
+		{">= 1.y.0", 3, "invalid minor"},
+		{"^", 1, "missing version"},
+		{"^1.x.3", 1, "value follows wildcard"},
+		{"^1.2-rc1", 1, "wrong length"},
+		{"^^1.2.3", 0, "unknown operator"},
+		{"1.0.0 ^ 01.2", 8, "invalid major"},
+		{"~", 1, "missing version"},
+		{"~1.2.y", 1, "invalid patch"},
+
+		// X-ranges.
+		{"1.x.0", 0, "value follows wildcard"},
+		{"*.1", 0, "value follows wildcard"},
+		{"1.2.x-rc1", 0, "wildcard not permitted with release"},
+		{"1.x+b", 0, "wildcard not permitted with release or build"},
+		{"1.2.3.x", 0, "wrong length"},
+		{"01.x", 0, "invalid major"},
+		{">=1.x", 0, "wildcard not permitted with operator"},
+		{"1.0.0 != *", 6, "wildcard not permitted with operator"},
+
+		// Hyphen ranges.
+		{"1.2.3 -", 7, "missing version"},
+		{"- 1.2.3", 0, "without lower bound"},
+		{"1.0.0 - 2.0.0 - 3.0.0", 14, "without lower bound"},
+		{">=1.2.3 - 2.0.0", 0, "operator not permitted"},
+		{"1.2.3 - <2.0.0", 8, "operator not permitted"},
+		{"1.2.z - 2.0.0", 0, "invalid patch"},
+		{"1.2.3 - 2.0.0-", 8, "empty release"},
+		{"1.2.3 - 2-rc1", 8, "wrong length"},
+		{"~^1.2.3", 0, "unknown operator"},
+	}
+	for _, tc := range tests {
+		c, err := semver.ParseConstraint(tc.input)
+		var perr *semver.ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("ParseConstraint(%q): got (%v, %v), want *ParseError", tc.input, c, err)
+			continue
+		}
+		if perr.Offset != tc.offset || !errContains(perr.Err, tc.errStr) {
+			t.Errorf("ParseConstraint(%q): got %v, want %q at offset %d", tc.input, err, tc.errStr, tc.offset)
+		}
+	}
+}
+
+func errContains(err error, s string) bool { return err != nil && strings.Contains(err.Error(), s) }
+
+func TestMustParseConstraint(t *testing.T) {
+	if got := semver.MustParseConstraint("^1.2.3").String(); got != ">=1.2.3 <2.0.0" {
+		t.Errorf("MustParseConstraint: got %q, want >=1.2.3 <2.0.0", got)
+	}
+	const bad = ">= 1.2.3 || <<2"
+	v := mtest.MustPanicf(t, func() { semver.MustParseConstraint(bad) }, "MustParseConstraint(%q) should panic", bad)
+	if msg := fmt.Sprint(v); !strings.Contains(msg, bad) {
+		t.Errorf("MustParseConstraint(%q): panic %q does not mention the input", bad, msg)
+	}
+}
+
+func TestIsValidConstraint(t *testing.T) {
+	for _, s := range []string{"1.2.3", ">=1.0.0 <2.0.0 || ^3", "*", "1.2 - 2"} {
+		if !semver.IsValidConstraint(s) {
+			t.Errorf("IsValidConstraint(%q): got false, want true", s)
+		}
+	}
+	for _, s := range []string{"", "1.2", "=>1.0.0", "1.0.0 ||", "1.x.3"} {
+		if semver.IsValidConstraint(s) {
+			t.Errorf("IsValidConstraint(%q): got true, want false", s)
+		}
+	}
+}
+
+func TestConstraintPrereleases(t *testing.T) {
+	tests := []struct {
+		input, version string
+		gated, raw     bool // whether version matches with and without gating
+	}{
+		{">=1.0.0", "1.2.3-rc1", false, true},
+		{">=1.0.0", "1.2.3", true, true},
+		{"^1.2.0", "1.2.3-rc1", false, true},
+		{"^1.2.0", "2.0.0-rc1", false, true},
+		{">=1.2.3-beta <1.3.0", "1.2.3-rc1", true, true},
+		{">=1.2.3-beta <1.3.0", "1.2.4-rc1", false, true},
+		{">=1.2.3-beta <1.3.0", "1.2.3-alpha", false, false},
+		{">=1.0.0 <1.2.3-rc2", "1.2.3-rc1", true, true},
+		{"1.2.3-rc1", "1.2.3-rc1+build", true, true},
+		{"!=1.2.3-rc2", "1.2.3-rc1", true, true},
+		{"!=1.2.3-rc2", "1.2.4-rc1", false, true},
+		{"^1.0.0 || >=1.2.3-rc0", "1.2.3-rc1", true, true},
+		{"*", "0.0.1-alpha", false, true},
+	}
+	for _, tc := range tests {
+		c := mustParseConstraint(t, tc.input)
+		v := mustParse(t, tc.version)
+		if got := c.Matches(v); got != tc.gated {
+			t.Errorf("Constraint %q: Matches(%v) = %v, want %v", c, v, got, tc.gated)
+		}
+		if got := c.AllowPrereleases().Matches(v); got != tc.raw {
+			t.Errorf("Constraint %q (allow prereleases): Matches(%v) = %v, want %v", c, v, got, tc.raw)
+		}
+	}
+
+	t.Run("IsSatisfiable", func(t *testing.T) {
+		c := mustParseConstraint(t, ">1.0.0 <1.0.1")
+		if c.IsSatisfiable() {
+			t.Errorf("[%q].IsSatisfiable(): got true, want false", c)
+		}
+		if !c.AllowPrereleases().IsSatisfiable() {
+			t.Errorf("[%q].AllowPrereleases().IsSatisfiable(): got false, want true", c)
+		}
+	})
+
+	t.Run("Combine", func(t *testing.T) {
+		a := mustParseConstraint(t, ">=1.0.0").AllowPrereleases()
+		b := mustParseConstraint(t, "<2.0.0")
+		v := mustParse(t, "1.5.0-rc1")
+		if !a.Intersect(a).Matches(v) {
+			t.Errorf("Intersect(%q, %q): does not match %v", a, a, v)
+		}
+		if a.Intersect(b).Matches(v) {
+			t.Errorf("Intersect(%q, gated %q) matches %v", a, b, v)
+		}
+		if !a.Union(b).Matches(v) {
+			t.Errorf("Union(%q, gated %q) does not match %v", a, b, v)
+		}
+	})
+
+	t.Run("Mixed", func(t *testing.T) {
+		// Each clause of a combination gates prereleases by the operands of its
+		// own sources, not by the pooled operands of both.
+		tests := []struct {
+			a, b    string
+			allowA  bool
+			version string
+			both    bool // whether the intersection matches version
+			either  bool // whether the union matches version
+		}{
+			{">=1.2.3-beta <2.0.0", ">=1.0.0 <1.5.0", false, "1.2.3-rc", false, true},
+			{">=1.0.0", "!=1.2.3-rc.1", false, "1.2.3-rc.2", false, true},
+			{">=1.0.0", "!=1.2.3-rc.1", false, "1.2.3-rc.1", false, false},
+			{">=1.0.0", ">=5.0.0", true, "1.2.0-rc", false, true},
+			{">=1.0.0", ">=1.2.0-beta", true, "1.2.0-rc", true, true},
+			{">=1.0.0", ">=1.2.0-beta", true, "1.3.0-rc", false, true},
+			{">=1.2.3-a <1.2.4", "<=1.2.3-z", false, "1.2.3-rc", true, true},
+		}
+		for _, tc := range tests {
+			a, b := mustParseConstraint(t, tc.a), mustParseConstraint(t, tc.b)
+			if tc.allowA {
+				a = a.AllowPrereleases()
+			}
+			v := mustParse(t, tc.version)
+			for _, c := range []semver.Constraint{a.Intersect(b), b.Intersect(a)} {
+				if got := c.Matches(v); got != tc.both {
+					t.Errorf("Intersect(%q, %q).Matches(%v): got %v, want %v", tc.a, tc.b, v, got, tc.both)
+				}
+			}
+			for _, c := range []semver.Constraint{a.Union(b), b.Union(a)} {
+				if got := c.Matches(v); got != tc.either {
+					t.Errorf("Union(%q, %q).Matches(%v): got %v, want %v", tc.a, tc.b, v, got, tc.either)
+				}
+			}
+			// The combinations must agree with the operands.
+			if want := a.Matches(v) && b.Matches(v); want != tc.both {
+				t.Errorf("%q and %q both match %v: %v, but test wants %v", tc.a, tc.b, v, want, tc.both)
+			}
+			if want := a.Matches(v) || b.Matches(v); want != tc.either {
+				t.Errorf("%q or %q matches %v: %v, but test wants %v", tc.a, tc.b, v, want, tc.either)
+			}
+		}
+
+		// Gated intersections are satisfiable only via versions both sides admit.
+		sat := []struct {
+			a, b string
+			want bool
+		}{
+			{">=1.2.3-beta <1.2.3", ">=1.2.3-alpha <1.2.3", true},
+			{">=1.2.3-beta <1.2.3", ">=1.0.0 <1.2.3", false},
+			{">1.0.0 <1.0.1", ">1.0.0", false},
+		}
+		for _, tc := range sat {
+			c := mustParseConstraint(t, tc.a).Intersect(mustParseConstraint(t, tc.b))
+			if got := c.IsSatisfiable(); got != tc.want {
+				t.Errorf("Intersect(%q, %q).IsSatisfiable(): got %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+		}
+	})
+}
+
+func TestConstraintIntersect(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+		sat  bool
+	}{
+		{">=1.0.0 <2.0.0", ">=1.5.0", ">=1.5.0 <2.0.0", true},
+		{">=1.5.0", ">=1.0.0 <2.0.0", ">=1.5.0 <2.0.0", true},
+		{"^1.2.0", "~1.4.0", ">=1.4.0 <1.5.0", true},
+		{">1.0.0", ">=1.0.0", ">1.0.0", true},
+		{"<2.0.0", "<=2.0.0", "<2.0.0", true},
+		{">=1.0.0 <2.0.0", ">=3.0.0", ">=3.0.0 <2.0.0", false},
+		{">=1.0.0 <=2.0.0", ">=2.0.0", "=2.0.0", true},
+		{">=1.0.0 <2.0.0", "<=1.0.0", "=1.0.0", true},
+		{">1.0.0", "<=1.0.0", ">1.0.0 <=1.0.0", false},
+		{"1.2.3", "!=1.2.3", "=1.2.3 !=1.2.3", false},
+		{"1.2.3", "1.2.4", ">=1.2.4 <=1.2.3", false},
+		{"^1.0.0", "!=1.5.0 !=3.0.0 !=1.5.0", ">=1.0.0 <2.0.0 !=1.5.0", true},
+		{"*", "1.x", ">=1.0.0 <2.0.0", true},
+		{"*", "*", "*", true},
+		{"1.x || 3.x", "^1.5.0 || ^3.1.0",
+			">=1.5.0 <2.0.0 || >=3.1.0 <2.0.0 || >=3.0.0 <2.0.0 || >=3.1.0 <4.0.0", true},
+		{"1.x || 3.x", "5.x", ">=5.0.0 <2.0.0 || >=5.0.0 <4.0.0", false},
+	}
+	for _, tc := range tests {
+		a, b := mustParseConstraint(t, tc.a), mustParseConstraint(t, tc.b)
+		got := a.Intersect(b)
+		if got.String() != tc.want {
+			t.Errorf("Intersect(%q, %q): got %q, want %q", a, b, got, tc.want)
+		}
+		if sat := got.IsSatisfiable(); sat != tc.sat {
+			t.Errorf("Intersect(%q, %q).IsSatisfiable(): got %v, want %v", a, b, sat, tc.sat)
+		}
+	}
+
+	var zero semver.Constraint
+	if got := zero.Intersect(mustParseConstraint(t, "*")); got.IsSatisfiable() {
+		t.Errorf("Intersect with zero: got %q, want unsatisfiable", got)
+	}
+}
+
+func TestConstraintUnion(t *testing.T) {
+	a, b := mustParseConstraint(t, "^1.0.0"), mustParseConstraint(t, "~3.1.0 || 5.0.0")
+	u := a.Union(b)
+	if got, want := u.String(), ">=1.0.0 <2.0.0 || >=3.1.0 <3.2.0 || =5.0.0"; got != want {
+		t.Errorf("Union(%q, %q): got %q, want %q", a, b, got, want)
+	}
+	for _, s := range []string{"1.0.0", "3.1.5", "5.0.0"} {
+		if v := mustParse(t, s); !u.Matches(v) {
+			t.Errorf("Union %q: Matches(%v) = false, want true", u, v)
+		}
+	}
+
+	var zero semver.Constraint
+	if got := zero.Union(a); got.String() != a.String() {
+		t.Errorf("Union with zero: got %q, want %q", got, a)
+	}
+}
+
+func TestConstraintIsSatisfiable(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"*", true},
+		{"1.2.3", true},
+		{">=1.0.0", true},
+		{"<0.0.0-0", false},
+		{"<=0.0.0-0", true},
+		{"!=0.0.0-0 <0.0.0-0.0", false},
+		{">=2.0.0 <1.0.0", false},
+		{">1.0.0 <1.0.1-0", false},
+		{">1.0.0 <=1.0.1-0", true},
+		{">1.0.0 <1.0.1-0.0 !=1.0.1-0", false},
+		{">1.0.0-rc <1.0.0-rc.0", false},
+		{">1.0.0-rc <1.0.0-rc.0.0", true},
+		{">=1.0.0 <=1.0.0 !=1.0.0", false},
+		{">=1.0.0 <=1.0.0 !=1.0.0+b", false},
+		{">=1.0.0 <1.0.1 !=1.0.0", false},
+		{">=1.0.0 <1.0.1-rc1 !=1.0.0", true},
+		{">1.0.0 <=1.0.1-rc1 !=1.0.1-rc1", true},
+		{">1.0.0 <=1.0.1-0 !=1.0.1-0", false},
+		{">=1.0.0 <1.0.2 !=1.0.0 !=1.0.1", false},
+		{">=1.0.0 <1.0.2 !=1.0.1", true},
+		{">1.0.0-rc1 <1.0.0", true},
+		{">1.0.0-rc1 <=1.0.0", true},
+		{">1.0.0-rc1 <1.0.0 !=1.0.0-rc1.0", true},
+		{">=0.9.0 <1.0.0-rc.1 !=0.9.0", true},
+		{">=2.0.0 <1.0.0 || 3.0.0", true},
+	}
+	for _, tc := range tests {
+		c := mustParseConstraint(t, tc.input)
+		if got := c.IsSatisfiable(); got != tc.want {
+			t.Errorf("[%q].IsSatisfiable(): got %v, want %v", c, got, tc.want)
+		}
+	}
+	if (semver.Constraint{}).IsSatisfiable() {
+		t.Error("Zero constraint is satisfiable")
+	}
+}
+
+func mustParseConstraint(t *testing.T, s string) semver.Constraint {
+	t.Helper()
+	c, err := semver.ParseConstraint(s)
+	if err != nil {
+		t.Fatalf("ParseConstraint(%q): unexpected error: %v", s, err)
+	}
+	return c
+}
@@ -0,0 +1,257 @@
+// Copyright (C) 2026 Michael J. Fromberger. All Rights Reserved.
+
+package semver
+
+import (
+	"cmp"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MarshalJSON implements the [json.Marshaler] interface. A version is encoded
+// as a JSON string containing its canonical form, as [V.String].
+// In particular, the zero version encodes as "0.0.0".
+func (v V) MarshalJSON() ([]byte, error) { return json.Marshal(v.String()) }
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface. It accepts a JSON
+// string in the format accepted by [V.UnmarshalText]. A JSON null leaves v
+// unmodified. Any other JSON value reports an error.
+func (v *V) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("version must be a JSON string, not %s", data)
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// MarshalBinary implements the [encoding.BinaryMarshaler] interface.
+// The encoding is the canonical string representation of v, as [V.String].
+// Because [encoding/gob] uses this method, a V may be encoded with gob.
+func (v V) MarshalBinary() ([]byte, error) { return []byte(v.String()), nil }
+
+// UnmarshalBinary implements the [encoding.BinaryUnmarshaler] interface.
+// The input must be a valid version string as accepted by [Parse].
+func (v *V) UnmarshalBinary(data []byte) error {
+	parsed, err := Parse(string(data))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// Set implements part of the [flag.Value] interface, so that a *V may be used
+// as a command-line flag. The input is cleaned and parsed as [ParseClean], so
+// a "v" prefix and partial versions like "1.2" are accepted.
+func (v *V) Set(s string) error {
+	parsed, err := ParseClean(s)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// UnmarshalJSONArray decodes data as a JSON array of strings, and parses each
+// string as a [V] using [Parse]. It reports an error identifying the index and
+// value of the first element that is not a string or is not a valid version.
+func UnmarshalJSONArray(data []byte) ([]V, error) {
+	var elts []json.RawMessage
+	if err := json.Unmarshal(data, &elts); err != nil {
+		return nil, err
+	}
+	out := make([]V, len(elts))
+	for i, elt := range elts {
+		var s string
+		if err := json.Unmarshal(elt, &s); err != nil || string(elt) == "null" {
+			return nil, fmt.Errorf("element %d: value %s is not a string", i, elt)
+		}
+		v, err := Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: value %q: %w", i, s, err)
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// ParseAny returns the [V] represented by x, which may be a string, an int,
+// an int64, a float64, or a [fmt.Stringer]. This is intended for use with
+// loosely-typed configuration formats, where a version may be decoded as a
+// number or a string. The value is converted to a string and cleaned and
+// parsed as [ParseClean]:
+//
+//   - A string is used as-is; a [fmt.Stringer] is converted by its String method.
+//   - An integer is formatted in decimal, so 1 becomes "1" (1.0.0).
+//   - A float64 is formatted in the shortest decimal notation that represents
+//     it exactly, as strconv.FormatFloat(x, 'f', -1, 64). Thus 1.2 becomes
+//     "1.2" (1.2.0), but note that 1.10 also becomes "1.1" (1.1.0), since the
+//     two are the same float64 value.
+//
+// A [V] is returned unmodified. Any other type reports an error.
+func ParseAny(x any) (V, error) {
+	var s string
+	switch t := x.(type) {
+	case V:
+		return t, nil
+	case string:
+		s = t
+	case int:
+		s = strconv.Itoa(t)
+	case int64:
+		s = strconv.FormatInt(t, 10)
+	case float64:
+		s = strconv.FormatFloat(t, 'f', -1, 64)
+	case fmt.Stringer:
+		s = t.String()
+	default:
+		return V{}, fmt.Errorf("unsupported version type %T", x)
+	}
+	return ParseClean(s)
+}
+
+// packBits is the number of bits allotted to each core field by [V.Pack].
+const packBits = 21
+
+// Pack packs the core version of v into a single uint64, and reports whether
+// this was possible. Packing requires that v has no release or build metadata,
+// and that each of the major, minor, and patch versions is less than 2^21.
+//
+// Packed values compare as uint64 in the same order as the core versions
+// they represent, so they may be used as compact sort or map keys.
+// Use [Unpack] to recover the version.
+func (v V) Pack() (uint64, bool) {
+	if v.release != "" || v.build != "" {
+		return 0, false
+	}
+	var u uint64
+	for _, f := range [...]string{v.major, v.minor, v.patch} {
+		n, ok := isNum(f)
+		if !ok || len(f) > 7 || n >= 1<<packBits {
+			return 0, false
+		}
+		u = u<<packBits | uint64(n)
+	}
+	return u, true
+}
+
+// Unpack returns the version packed into u by [V.Pack].
+// Bits of u not used by Pack are ignored.
+func Unpack(u uint64) V {
+	const mask = 1<<packBits - 1
+	return New(int(u>>(2*packBits))&mask, int(u>>packBits)&mask, int(u)&mask)
+}
+
+// SortKey returns a string encoding the precedence of v, such that for any
+// versions v and w, strings.Compare(v.SortKey(), w.SortKey()) has the same
+// sign as Compare(v, w). Thus sort keys may be used as ordered keys in a
+// database or key-value store. Build metadata are not encoded, so equivalent
+// versions have identical keys.
+//
+// The key consists of the encodings of the major, minor, and patch versions
+// as numbers (see below), followed by:
+//
+//   - If v has no release label, the byte "~".
+//   - Otherwise, for each dot-separated identifier of the release label in
+//     order: if the identifier is numeric, the byte "#" followed by its
+//     encoding as a number; otherwise the byte "$" followed by the identifier.
+//
+// A number whose decimal representation (without leading zeroes) is D, of
+// length L, is encoded as the number of decimal digits in L as a single
+// digit, followed by L in decimal, followed by D. For example, 5 encodes as
+// "115", 12 as "1212", and 1234567890 as "2101234567890".
+//
+// For example, the key for 1.2.3 is "111112113~", and the key for
+// 1.0.0-rc.12 is "111110110$rc#1212".
+func (v V) SortKey() string {
+	var buf []byte
+	for _, f := range [...]string{v.major, v.minor, v.patch} {
+		buf = appendSortNum(buf, f)
+	}
+	if v.release == "" {
+		return string(append(buf, '~'))
+	}
+	for s := v.release; s != ""; {
+		w, rest := cutDotWord(s)
+		if _, ok := isNum(w); ok {
+			buf = appendSortNum(append(buf, '#'), w)
+		} else {
+			buf = append(append(buf, '$'), w...)
+		}
+		s = rest
+	}
+	return string(buf)
+}
+
+// appendSortNum appends the sort key encoding of the digit string s to buf.
+// An empty string is encoded as 0.
+func appendSortNum(buf []byte, s string) []byte {
+	s = cmp.Or(strings.TrimLeft(s, "0"), "0")
+	n := strconv.Itoa(len(s))
+	buf = append(buf, byte('0'+len(n)))
+	return append(append(buf, n...), s...)
+}
+
+// Value implements the [driver.Valuer] interface. It returns the canonical
+// string representation of v, as [V.String].
+func (v V) Value() (driver.Value, error) { return v.String(), nil }
+
+// Scan implements the [database/sql.Scanner] interface. It accepts a string
+// or []byte value, which must be a valid version string as accepted by
+// [Parse]. Scanning a nil value sets v to the zero [V].
+func (v *V) Scan(src any) error {
+	var s string
+	switch t := src.(type) {
+	case nil:
+		*v = V{}
+		return nil
+	case string:
+		s = t
+	case []byte:
+		s = string(t)
+	default:
+		return fmt.Errorf("cannot scan %T into a version", src)
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return fmt.Errorf("scan version %q: %w", s, err)
+	}
+	*v = parsed
+	return nil
+}
+
+// NullV represents a [V] that may be null, for use as a scan destination
+// for a nullable database column. It is analogous to [database/sql.NullString].
+type NullV struct {
+	V     V
+	Valid bool // Valid is true if V is not NULL
+}
+
+// Scan implements the [database/sql.Scanner] interface.
+func (n *NullV) Scan(src any) error {
+	if src == nil {
+		n.V, n.Valid = V{}, false
+		return nil
+	}
+	if err := n.V.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements the [driver.Valuer] interface.
+// An invalid NullV has the value nil.
+func (n NullV) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.V.Value()
+}
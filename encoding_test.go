@@ -0,0 +1,375 @@
+// Copyright (C) 2026 Michael J. Fromberger. All Rights Reserved.
+
+package semver_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/creachadair/semver"
+)
+
+func TestJSON(t *testing.T) {
+	type config struct {
+		Name    string   `json:"name"`
+		Version semver.V `json:"version"`
+	}
+	t.Run("Marshal", func(t *testing.T) {
+		tests := []struct {
+			input semver.V
+			want  string
+		}{
+			{semver.V{}, `{"name":"x","version":"0.0.0"}`},
+			{semver.New(1, 2, 3), `{"name":"x","version":"1.2.3"}`},
+			{semver.MustParse("1.2.3-rc1+build"), `{"name":"x","version":"1.2.3-rc1+build"}`},
+		}
+		for _, tc := range tests {
+			got, err := json.Marshal(config{Name: "x", Version: tc.input})
+			if err != nil {
+				t.Errorf("Marshal %v: unexpected error: %v", tc.input, err)
+			} else if string(got) != tc.want {
+				t.Errorf("Marshal %v: got %#q, want %#q", tc.input, got, tc.want)
+			}
+		}
+	})
+	t.Run("Unmarshal", func(t *testing.T) {
+		tests := []struct {
+			input   string
+			want    string
+			errText string
+		}{
+			{`{"version":"1.2.3-rc1+build"}`, "1.2.3-rc1+build", ""},
+			{`{"version":"v1.0.0"}`, "1.0.0", ""},
+			{`{"version":null}`, "9.9.9", ""}, // unmodified
+			{`{}`, "9.9.9", ""},
+
+			{`{"version":"1.2"}`, "", "wrong length"},
+			{`{"version":123}`, "", "version must be a JSON string, not 123"},
+			{`{"version":["1.2.3"]}`, "", `version must be a JSON string, not ["1.2.3"]`},
+		}
+		for _, tc := range tests {
+			cfg := config{Version: semver.New(9, 9, 9)}
+			err := json.Unmarshal([]byte(tc.input), &cfg)
+			if tc.errText != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errText) {
+					t.Errorf("Unmarshal %#q: got (%v, %v), want error %q", tc.input, cfg.Version, err, tc.errText)
+				}
+			} else if err != nil {
+				t.Errorf("Unmarshal %#q: unexpected error: %v", tc.input, err)
+			} else if got := cfg.Version.String(); got != tc.want {
+				t.Errorf("Unmarshal %#q: got %q, want %q", tc.input, got, tc.want)
+			}
+		}
+	})
+	t.Run("RoundTrip", func(t *testing.T) {
+		for _, s := range []string{"0.0.0", "1.2.3", "1.0.0-alpha.1+x.y"} {
+			v := semver.MustParse(s)
+			bits, err := json.Marshal(v)
+			if err != nil {
+				t.Fatalf("Marshal %v: %v", v, err)
+			}
+			var w semver.V
+			if err := json.Unmarshal(bits, &w); err != nil {
+				t.Fatalf("Unmarshal %#q: %v", bits, err)
+			}
+			if w != v {
+				t.Errorf("Round trip %v: got %v", v, w)
+			}
+		}
+	})
+}
+
+func TestBinary(t *testing.T) {
+	vs := []semver.V{{}, semver.New(1, 2, 3), semver.MustParse("1.0.0-alpha.1+x.y")}
+	for _, v := range vs {
+		bits, err := v.MarshalBinary()
+		if err != nil {
+			t.Fatalf("[%v].MarshalBinary(): unexpected error: %v", v, err)
+		}
+		var w semver.V
+		if err := w.UnmarshalBinary(bits); err != nil {
+			t.Fatalf("UnmarshalBinary %q: unexpected error: %v", bits, err)
+		}
+		if w.String() != v.String() {
+			t.Errorf("Binary round trip %v: got %v", v, w)
+		}
+	}
+	for _, bad := range []string{"", "v1.2.3", "1.2.3 ", "1.2.3\x00", "1.2.3+x\n"} {
+		var w semver.V
+		if err := w.UnmarshalBinary([]byte(bad)); err == nil {
+			t.Errorf("UnmarshalBinary %q: got %v, want error", bad, w)
+		}
+	}
+
+	t.Run("Gob", func(t *testing.T) {
+		type entry struct {
+			Name string
+			Vers []semver.V
+		}
+		in := entry{Name: "index", Vers: vs}
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+			t.Fatalf("Encode: unexpected error: %v", err)
+		}
+		var out entry
+		if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+			t.Fatalf("Decode: unexpected error: %v", err)
+		}
+		checkVersions(t, "gob", out.Vers, "0.0.0", "1.2.3", "1.0.0-alpha.1+x.y")
+	})
+}
+
+func TestFlag(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    string
+		errText string
+	}{
+		{nil, "1.0.0", ""},
+		{[]string{"-min", "2.3.4-rc1"}, "2.3.4-rc1", ""},
+		{[]string{"-min", "v1.2"}, "1.2.0", ""},
+		{[]string{"-min=3"}, "3.0.0", ""},
+		{[]string{"-min", "bogus"}, "", `invalid value "bogus" for flag -min: invalid major`},
+		{[]string{"-min", "1.2.3.4"}, "", `invalid value "1.2.3.4" for flag -min: wrong length`},
+	}
+	for _, tc := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		min := semver.New(1, 0, 0)
+		fs.Var(&min, "min", "minimum version")
+
+		err := fs.Parse(tc.args)
+		if tc.errText != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.errText) {
+				t.Errorf("Parse %q: got (%v, %v), want error %q", tc.args, min, err, tc.errText)
+			}
+		} else if err != nil {
+			t.Errorf("Parse %q: unexpected error: %v", tc.args, err)
+		} else if min.String() != tc.want {
+			t.Errorf("Parse %q: got %v, want %q", tc.args, min, tc.want)
+		}
+	}
+}
+
+func TestUnmarshalJSONArray(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    []string
+		errText string
+	}{
+		{`[]`, nil, ""},
+		{`["1.2.3"]`, []string{"1.2.3"}, ""},
+		{` [ "1.2.3", "1.3.0-rc1+b" ] `, []string{"1.2.3", "1.3.0-rc1+b"}, ""},
+
+		{`["1.2.3", "1.2", "1.3.0"]`, nil, `element 1: value "1.2": wrong length`},
+		{`["1.2.3", "v1.2.4"]`, nil, `element 1: value "v1.2.4": invalid major`},
+		{`["1.2.3", 5, "1.3.0"]`, nil, `element 1: value 5 is not a string`},
+		{`["1.2.3", null]`, nil, `element 1: value null is not a string`},
+		{`{"a": "1.2.3"}`, nil, "cannot unmarshal"},
+		{`["1.2.3"`, nil, "unexpected end"},
+	}
+	for _, tc := range tests {
+		got, err := semver.UnmarshalJSONArray([]byte(tc.input))
+		if tc.errText != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.errText) {
+				t.Errorf("UnmarshalJSONArray(%#q): got (%v, %v), want error %q", tc.input, got, err, tc.errText)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("UnmarshalJSONArray(%#q): unexpected error: %v", tc.input, err)
+			continue
+		}
+		checkVersions(t, "UnmarshalJSONArray", got, tc.want...)
+	}
+}
+
+type stringer string
+
+func (s stringer) String() string { return string(s) }
+
+func TestParseAny(t *testing.T) {
+	tests := []struct {
+		input   any
+		want    string
+		errText string
+	}{
+		{"1.2.3-rc1", "1.2.3-rc1", ""},
+		{"v1.2", "1.2.0", ""},
+		{1, "1.0.0", ""},
+		{int64(25), "25.0.0", ""},
+		{1.2, "1.2.0", ""},
+		{1.10, "1.1.0", ""},
+		{3.0, "3.0.0", ""},
+		{stringer("v2.0.1+x"), "2.0.1+x", ""},
+		{semver.MustParse("4.5.6-a+b"), "4.5.6-a+b", ""},
+
+		{-1, "", "wrong length"},
+		{-2.5, "", "wrong length"},
+		{"nonsense", "", "invalid major"},
+		{true, "", "unsupported version type bool"},
+		{nil, "", "unsupported version type <nil>"},
+		{[]byte("1.2.3"), "", "unsupported version type []uint8"},
+	}
+	for _, tc := range tests {
+		got, err := semver.ParseAny(tc.input)
+		if tc.errText != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.errText) {
+				t.Errorf("ParseAny(%#v): got (%v, %v), want error %q", tc.input, got, err, tc.errText)
+			}
+		} else if err != nil {
+			t.Errorf("ParseAny(%#v): unexpected error: %v", tc.input, err)
+		} else if got.String() != tc.want {
+			t.Errorf("ParseAny(%#v): got %v, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestPack(t *testing.T) {
+	vs := mustParseAll(t,
+		"0.0.0", "0.0.1", "0.1.0", "0.1.9", "1.0.0", "1.0.10", "1.9.0", "1.10.0",
+		"2.0.0", "2097151.0.0", "2097151.2097151.2097151",
+	)
+	var prev uint64
+	for i, v := range vs {
+		u, ok := v.Pack()
+		if !ok {
+			t.Errorf("[%v].Pack(): unexpectedly failed", v)
+			continue
+		}
+		if i > 0 && u <= prev {
+			t.Errorf("[%v].Pack(): got %#x, not greater than %v (%#x)", v, u, vs[i-1], prev)
+		}
+		prev = u
+		if got := semver.Unpack(u); got != v {
+			t.Errorf("Unpack(%#x): got %v, want %v", u, got, v)
+		}
+	}
+	if u, ok := (semver.V{}).Pack(); !ok || u != 0 {
+		t.Errorf("Zero Pack: got (%#x, %v), want (0, true)", u, ok)
+	}
+
+	for _, s := range []string{
+		"2097152.0.0", "0.2097152.0", "0.0.2097152", "99999999999999999999.0.0",
+		"1.0.0-rc1", "1.0.0+build",
+	} {
+		v := mustParse(t, s)
+		if u, ok := v.Pack(); ok {
+			t.Errorf("[%v].Pack(): got (%#x, true), want false", v, u)
+		}
+	}
+}
+
+func TestSortKey(t *testing.T) {
+	tests := []struct {
+		input, want string
+	}{
+		{"0.0.0", "110110110~"},
+		{"1.2.3", "111112113~"},
+		{"1.2.3+build", "111112113~"},
+		{"12.0.1234567890", "1212110" + "2101234567890" + "~"},
+		{"1.0.0-rc.12", "111110110$rc#1212"},
+		{"1.0.0-0.a-b", "111110110#110$a-b"},
+	}
+	for _, tc := range tests {
+		v := mustParse(t, tc.input)
+		if got := v.SortKey(); got != tc.want {
+			t.Errorf("[%v].SortKey(): got %q, want %q", v, got, tc.want)
+		}
+	}
+	if got, want := (semver.V{}).SortKey(), semver.New(0, 0, 0).SortKey(); got != want {
+		t.Errorf("Zero SortKey: got %q, want %q", got, want)
+	}
+
+	// Sort keys must order the same as the versions they encode.
+	vs := mustParseAll(t,
+		"0.0.0", "0.0.1", "0.1.0", "1.0.0", "1.0.10", "1.9.0", "1.10.0", "2.0.0",
+		"9.0.0", "10.0.0", "99999999999999999999.0.0", "100000000000000000000.0.0",
+		"1.0.0-0", "1.0.0-1", "1.0.0-2", "1.0.0-10", "1.0.0-10a", "1.0.0--x", "1.0.0-A", "1.0.0-a",
+		"1.0.0-a.0", "1.0.0-a.1", "1.0.0-a.b", "1.0.0-a-", "1.0.0-a.1.2", "1.0.0-ab", "1.0.0-b",
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0+b", "1.0.1-0", "1.0.0-rc.18446744073709551616",
+	)
+	for _, a := range vs {
+		for _, b := range vs {
+			want := semver.Compare(a, b)
+			if got := strings.Compare(a.SortKey(), b.SortKey()); got != want {
+				t.Errorf("Compare keys (%v, %v): got %d, want %d [%q, %q]",
+					a, b, got, want, a.SortKey(), b.SortKey())
+			}
+		}
+	}
+}
+
+func TestSQL(t *testing.T) {
+	t.Run("Value", func(t *testing.T) {
+		for _, v := range []semver.V{{}, semver.MustParse("1.2.3-rc1+b")} {
+			got, err := v.Value()
+			if err != nil {
+				t.Errorf("[%v].Value(): unexpected error: %v", v, err)
+			} else if got != v.String() {
+				t.Errorf("[%v].Value(): got %#v, want %q", v, got, v.String())
+			}
+		}
+	})
+	t.Run("Scan", func(t *testing.T) {
+		tests := []struct {
+			input   any
+			want    string
+			errText string
+		}{
+			{"1.2.3", "1.2.3", ""},
+			{[]byte("1.2.3-rc1+b"), "1.2.3-rc1+b", ""},
+			{nil, "0.0.0", ""},
+
+			{"1.2", "", `scan version "1.2": wrong length`},
+			{[]byte("v1.0.0"), "", `scan version "v1.0.0": invalid major`},
+			{int64(5), "", "cannot scan int64 into a version"},
+		}
+		for _, tc := range tests {
+			v := semver.New(9, 9, 9)
+			err := v.Scan(tc.input)
+			if tc.errText != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errText) {
+					t.Errorf("Scan(%#v): got (%v, %v), want error %q", tc.input, v, err, tc.errText)
+				}
+			} else if err != nil {
+				t.Errorf("Scan(%#v): unexpected error: %v", tc.input, err)
+			} else if v.String() != tc.want {
+				t.Errorf("Scan(%#v): got %v, want %q", tc.input, v, tc.want)
+			}
+		}
+	})
+	t.Run("NullV", func(t *testing.T) {
+		var n semver.NullV
+		if err := n.Scan("1.2.3"); err != nil {
+			t.Fatalf("Scan: unexpected error: %v", err)
+		}
+		if !n.Valid || n.V.String() != "1.2.3" {
+			t.Errorf("Scan 1.2.3: got %+v", n)
+		}
+		if val, err := n.Value(); err != nil || val != "1.2.3" {
+			t.Errorf("Value: got (%#v, %v), want 1.2.3", val, err)
+		}
+
+		if err := n.Scan(nil); err != nil {
+			t.Fatalf("Scan nil: unexpected error: %v", err)
+		}
+		if n.Valid {
+			t.Errorf("Scan nil: got %+v, want invalid", n)
+		}
+		if val, err := n.Value(); err != nil || val != nil {
+			t.Errorf("Value: got (%#v, %v), want nil", val, err)
+		}
+
+		if err := n.Scan("bogus"); err == nil {
+			t.Error("Scan bogus: got nil, want error")
+		} else if n.Valid {
+			t.Errorf("Scan bogus: got %+v, want invalid", n)
+		}
+	})
+}
@@ -0,0 +1,310 @@
+// Copyright (C) 2026 Michael J. Fromberger. All Rights Reserved.
+
+package semver
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ANSI escape sequences used by [DiffString] to highlight the changed portion
+// of a version, selected by the first field that differs.
+const (
+	ansiMajor = "\x1b[31m" // red
+	ansiMinor = "\x1b[33m" // yellow
+	ansiPatch = "\x1b[32m" // green
+	ansiLabel = "\x1b[36m" // cyan, for release or build changes
+	ansiReset = "\x1b[0m"
+)
+
+// DiffString returns the string representation of to, in which the first
+// field that differs from from, and everything following it, is highlighted
+// using ANSI terminal escape sequences. Leading fields that are unchanged
+// from from are left plain.
+//
+// The highlighted portion begins with one of the following sequences,
+// depending on which field first differs, and ends with ESC[0m (reset):
+//
+//	ESC[31m  red     major version
+//	ESC[33m  yellow  minor version
+//	ESC[32m  green   patch version
+//	ESC[36m  cyan    release or build metadata
+//
+// If from and to are identical, or differ only in labels that to lacks, as
+// for 1.2.3-rc1 ⇒ 1.2.3, the result is to.String() with no escapes.
+// For output that is not a terminal, use [DiffStringMarked].
+func DiffString(from, to V) string {
+	s, pos, field := diffPos(from, to)
+	if pos < 0 {
+		return s
+	}
+	start := [...]string{ansiMajor, ansiMinor, ansiPatch, ansiLabel}[field]
+	return s[:pos] + start + s[pos:] + ansiReset
+}
+
+// DiffStringMarked is as [DiffString], but brackets the changed portion of
+// to with the given begin and end strings rather than ANSI escapes.
+// For example, DiffStringMarked(New(1, 2, 3), New(1, 3, 0), "[", "]")
+// returns "1.[3.0]".
+func DiffStringMarked(from, to V, begin, end string) string {
+	s, pos, _ := diffPos(from, to)
+	if pos < 0 {
+		return s
+	}
+	return s[:pos] + begin + s[pos:] + end
+}
+
+// diffPos returns the string representation of to, the byte offset in that
+// string of the first field that differs from from, and an index identifying
+// that field (0=major, 1=minor, 2=patch, 3=release or build).
+// If from and to are identical, or if the only difference is a label removed
+// from to so that there is nothing in s to mark, diffPos reports pos < 0.
+func diffPos(from, to V) (s string, pos, field int) {
+	s = to.String()
+	ma, mi, pa := cmp.Or(to.major, "0"), cmp.Or(to.minor, "0"), cmp.Or(to.patch, "0")
+	switch {
+	case compareNum(from.major, to.major) != 0:
+		return s, 0, 0
+	case compareNum(from.minor, to.minor) != 0:
+		return s, len(ma) + 1, 1
+	case compareNum(from.patch, to.patch) != 0:
+		return s, len(ma) + len(mi) + 2, 2
+	case from.release != to.release || from.build != to.build:
+		// Include the "-" or "+" marker of the first differing label.
+		pos = len(ma) + len(mi) + len(pa) + 2
+		if from.release == to.release && to.release != "" {
+			pos += len(to.release) + 1
+		}
+		if pos == len(s) {
+			return s, -1, 0
+		}
+		return s, pos, 3
+	}
+	return s, -1, 0
+}
+
+// Format implements the [fmt.Formatter] interface. It supports the verbs:
+//
+//	%s, %v  the string representation of v, as [V.String]: 1.2.3
+//	%+s, %+v  the same, with a "v" prefix as for Go modules: v1.2.3
+//	%q      the string representation of v as a quoted Go string: "1.2.3"
+//	%#v     a Go expression for v: semver.MustParse("1.2.3")
+//
+// The width and precision and the "-" flag are applied to the resulting
+// string as for %s. Other verbs are reported as errors in the usual way.
+func (v V) Format(f fmt.State, verb rune) {
+	var s string
+	switch verb {
+	case 's', 'v', 'q':
+		s = v.String()
+		if verb == 'v' && f.Flag('#') {
+			if v == (V{}) {
+				s = "semver.V{}"
+			} else {
+				s = fmt.Sprintf("semver.MustParse(%q)", s)
+			}
+		} else if verb == 'q' {
+			s = strconv.Quote(s)
+		} else if f.Flag('+') {
+			s = "v" + s
+		}
+	default:
+		fmt.Fprintf(f, "%%!%c(semver.V=%s)", verb, v.String())
+		return
+	}
+	dir := "%"
+	if f.Flag('-') {
+		dir += "-"
+	}
+	if w, ok := f.Width(); ok {
+		dir += strconv.Itoa(w)
+	}
+	if p, ok := f.Precision(); ok {
+		dir += "." + strconv.Itoa(p)
+	}
+	fmt.Fprintf(f, dir+"s", s)
+}
+
+// Series returns a label for the release series of v, consisting of prefix
+// followed by the major and minor versions of v, for example "v1.2".
+// The patch version, release label, and build metadata are ignored.
+func (v V) Series(prefix string) string {
+	return prefix + cmp.Or(v.major, "0") + "." + cmp.Or(v.minor, "0")
+}
+
+// CaretRange returns a caret constraint string admitting versions compatible
+// with the core of v, for example "^1.2.3". Release and build metadata are
+// omitted.
+//
+// By the usual convention, a caret constraint permits changes that do not
+// modify the left-most non-zero core field. Thus "^1.2.3" admits versions in
+// [1.2.3, 2.0.0), but "^0.2.3" admits only [0.2.3, 0.3.0), and "^0.0.3"
+// admits only [0.0.3, 0.0.4).
+func (v V) CaretRange() string { return "^" + v.Core().String() }
+
+// TildeRange returns a tilde constraint string admitting patch-level changes
+// to the core of v, for example "~1.2.3", which admits versions in
+// [1.2.3, 1.3.0). Release and build metadata are omitted.
+func (v V) TildeRange() string { return "~" + v.Core().String() }
+
+// ToMaven renders v in Maven version notation. The core version and release
+// label are preserved, so that a semver prerelease "1.2.3-rc.1" renders as the
+// Maven qualifier "1.2.3-rc.1" and "1.2.3-SNAPSHOT" renders unchanged. Build
+// metadata have no Maven equivalent, and are discarded.
+func ToMaven(v V) string { return v.Key().String() }
+
+// FromMaven parses a Maven version string into a [V]. The mapping is:
+//
+//   - A "major[.minor[.patch]]" prefix is cleaned as [Clean] does.
+//   - A ".RELEASE" or "-RELEASE" suffix (in any case) denotes a stable
+//     release, and is removed.
+//   - A qualifier consisting only of digits, as in "1.2.3-4", is a Maven build
+//     number and becomes build metadata: "1.2.3+4".
+//   - Any other qualifier, as in "1.2.3-SNAPSHOT", becomes the release label.
+//
+// The mapping is lossy: Maven and semver disagree on the ordering of many
+// qualifiers. For example, Maven orders "1.2.3-4" after "1.2.3" and treats
+// "1.2.3-ga" as equivalent to "1.2.3", whereas semver does neither.
+func FromMaven(s string) (V, error) {
+	s = strings.TrimSpace(s)
+	for _, tail := range []string{".RELEASE", "-RELEASE"} {
+		if n := len(s) - len(tail); n > 0 && strings.EqualFold(s[n:], tail) {
+			s = s[:n]
+			break
+		}
+	}
+	core, qual, hasQual := strings.Cut(s, "-")
+	if strings.Contains(s, "+") {
+		return V{}, invalidThingError{"maven version", s, ErrBuildMetadata}
+	}
+	v, err := ParseClean(core)
+	if err != nil || !hasQual {
+		return v, err
+	}
+	if qual == "" {
+		return V{}, ErrEmptyRelease
+	} else if _, ok := isNum(qual); ok {
+		v.build = qual
+	} else if err := checkRelease(qual); err != nil {
+		return V{}, invalidThingError{"qualifier", qual, err}
+	} else {
+		v.release = qual
+	}
+	return v, nil
+}
+
+// ToModule renders v in the canonical form used for Go module versions, as
+// produced by golang.org/x/mod/semver.Canonical: a "v" prefix followed by the
+// core version and release label, for example "v1.2.3-rc.1". Build metadata
+// are discarded, since the module system ignores them.
+func (v V) ToModule() string { return v.Key().StringV() }
+
+// FromModule parses a Go module version string into a [V]. The string must
+// have a "v" prefix, and may otherwise be any string accepted by
+// golang.org/x/mod/semver.IsValid, including the shorthand forms "v1" and
+// "v1.2", which denote "v1.0.0" and "v1.2.0" respectively. Build metadata,
+// such as "+incompatible", are preserved.
+//
+// Ordering of the resulting versions by [Compare] agrees with the ordering
+// of the original strings by golang.org/x/mod/semver.Compare.
+func FromModule(s string) (V, error) {
+	rest, ok := strings.CutPrefix(s, "v")
+	if !ok {
+		return V{}, invalidThingError{"module version", s, errMissingPrefix}
+	}
+	if !strings.ContainsAny(rest, "-+") {
+		// The shorthand forms do not permit release or build labels.
+		for n := strings.Count(rest, "."); n < 2; n++ {
+			rest += ".0"
+		}
+	}
+	v, err := Parse(rest)
+	if err != nil {
+		return V{}, invalidThingError{"module version", s, err}
+	}
+	return v, nil
+}
+
+// ParseDescribe parses the output of "git describe --tags" into a [V]. The
+// output has the form TAG[-N-gHASH][-dirty], where TAG is the most recent
+// tag, N is the number of commits since that tag, and HASH is an abbreviated
+// commit hash. The tag is parsed as [ParseClean], so it may have a "v"
+// prefix. The commit count, hash, and dirty marker, if present, are appended
+// to the build metadata of the tag, in that order. For example:
+//
+//	v1.2.3                     ⇒  1.2.3
+//	v1.2.3-5-gabc1234          ⇒  1.2.3+5.gabc1234
+//	v1.2.3-rc.1-5-gabc1234     ⇒  1.2.3-rc.1+5.gabc1234
+//	v1.2.3-5-gabc1234-dirty    ⇒  1.2.3+5.gabc1234.dirty
+//
+// Because build metadata do not affect precedence, the result is equivalent
+// to the tag (see [V.Equiv]), even though it describes a later commit. Use
+// [V.NextInWorkflow] or similar to derive a version that orders after the
+// tag, if needed.
+func ParseDescribe(s string) (V, error) {
+	tag, dirty := strings.CutSuffix(strings.TrimSpace(s), "-dirty")
+	var extra []string
+	if i := strings.LastIndexByte(tag, '-'); i > 0 && isDescribeHash(tag[i+1:]) {
+		if j := strings.LastIndexByte(tag[:i], '-'); j > 0 && isDescribeCount(tag[j+1:i]) {
+			extra = append(extra, tag[j+1:i], tag[i+1:])
+			tag = tag[:j]
+		}
+	}
+	if dirty {
+		extra = append(extra, "dirty")
+	}
+	v, err := ParseClean(tag)
+	if err != nil {
+		return V{}, invalidThingError{"tag", tag, err}
+	}
+	if len(extra) != 0 {
+		v.build = strings.Join(append(splitWords(v.build), extra...), ".")
+	}
+	return v, nil
+}
+
+// isDescribeCount reports whether s is a commit count from git describe.
+func isDescribeCount(s string) bool {
+	_, ok := isNum(s)
+	return ok && s != ""
+}
+
+// isDescribeHash reports whether s is an abbreviated commit hash from git
+// describe, consisting of "g" followed by lower-case hexadecimal digits.
+func isDescribeHash(s string) bool {
+	hex, ok := strings.CutPrefix(s, "g")
+	return ok && hex != "" && strings.Trim(hex, "0123456789abcdef") == ""
+}
+
+// PadPrerelease returns the string representation of v, in which each
+// numeric identifier of the release label is padded with leading zeroes to
+// at least digits places. The core version and build metadata are unchanged.
+// For example, 1.0.0-rc.2 padded to 3 digits is "1.0.0-rc.002".
+//
+// This is intended for aligned display only. Numeric release identifiers with
+// leading zeroes are not valid, so the result is NOT a valid semantic version
+// string and must not be passed to [Parse].
+func (v V) PadPrerelease(digits int) string {
+	if v.release == "" {
+		return v.String()
+	}
+	var sb strings.Builder
+	for i, s := 0, v.release; s != ""; i++ {
+		w, rest := cutDotWord(s)
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+		if _, ok := isNum(w); ok && len(w) < digits {
+			sb.WriteString(strings.Repeat("0", digits-len(w)))
+		}
+		sb.WriteString(w)
+		s = rest
+	}
+	out := v.Core().String() + "-" + sb.String()
+	if v.build != "" {
+		out += "+" + v.build
+	}
+	return out
+}
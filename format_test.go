@@ -0,0 +1,353 @@
+// Copyright (C) 2026 Michael J. Fromberger. All Rights Reserved.
+
+package semver_test
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/creachadair/semver"
+)
+
+func TestDiffString(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     string // with [ and ] marking the highlight
+	}{
+		{"1.2.3", "1.2.3", "1.2.3"},
+		{"1.2.3+a", "1.2.3+a", "1.2.3+a"},
+		{"1.2.3", "2.0.0", "[2.0.0]"},
+		{"1.2.3", "1.3.0", "1.[3.0]"},
+		{"1.2.3", "1.2.4", "1.2.[4]"},
+		{"1.2.3", "1.20.3", "1.[20.3]"},
+		{"10.2.3", "10.2.30-rc1", "10.2.[30-rc1]"},
+		{"1.2.3-rc1", "1.2.3-rc2+x", "1.2.3[-rc2+x]"},
+		{"1.2.3-rc1+x", "1.2.3-rc1+y", "1.2.3-rc1[+y]"},
+		{"1.2.3", "1.2.3+y", "1.2.3[+y]"},
+		{"1.2.3-rc1", "1.2.3", "1.2.3"},
+		{"1.2.3-rc1+x", "1.2.3-rc1", "1.2.3-rc1"},
+		{"1.2.3+x", "1.2.3", "1.2.3"},
+		{"1.99999999999999999998.0", "1.99999999999999999999.0", "1.[99999999999999999999.0]"},
+	}
+	for _, tc := range tests {
+		from, to := mustParse(t, tc.from), mustParse(t, tc.to)
+		if got := semver.DiffStringMarked(from, to, "[", "]"); got != tc.want {
+			t.Errorf("DiffStringMarked(%v, %v): got %q, want %q", from, to, got, tc.want)
+		}
+	}
+
+	colors := []struct {
+		from, to string
+		want     string
+	}{
+		{"1.2.3", "1.2.3", "1.2.3"},
+		{"1.2.3", "2.0.0", "\x1b[31m2.0.0\x1b[0m"},
+		{"1.2.3", "1.3.0", "1.\x1b[33m3.0\x1b[0m"},
+		{"1.2.3", "1.2.4", "1.2.\x1b[32m4\x1b[0m"},
+		{"1.2.3", "1.2.3-rc1", "1.2.3\x1b[36m-rc1\x1b[0m"},
+		{"1.2.3-rc1", "1.2.3", "1.2.3"},
+	}
+	for _, tc := range colors {
+		from, to := mustParse(t, tc.from), mustParse(t, tc.to)
+		if got := semver.DiffString(from, to); got != tc.want {
+			t.Errorf("DiffString(%v, %v): got %q, want %q", from, to, got, tc.want)
+		}
+	}
+}
+
+func TestFormatter(t *testing.T) {
+	v := mustParse(t, "1.2.3-rc1+b")
+	tests := []struct {
+		format string
+		input  semver.V
+		want   string
+	}{
+		{"%v", v, "1.2.3-rc1+b"},
+		{"%s", v, "1.2.3-rc1+b"},
+		{"%+v", v, "v1.2.3-rc1+b"},
+		{"%+s", v, "v1.2.3-rc1+b"},
+		{"%q", v, `"1.2.3-rc1+b"`},
+		{"%#v", v, `semver.MustParse("1.2.3-rc1+b")`},
+		{"%#v", semver.V{}, "semver.V{}"},
+		{"%#v", semver.New(0, 0, 0), `semver.MustParse("0.0.0")`},
+		{"%v", semver.V{}, "0.0.0"},
+		{"[%8v]", semver.New(1, 2, 3), "[   1.2.3]"},
+		{"[%-8v]", semver.New(1, 2, 3), "[1.2.3   ]"},
+		{"[%-+8v]", semver.New(1, 2, 3), "[v1.2.3  ]"},
+		{"[%.3s]", semver.New(1, 2, 3), "[1.2]"},
+		{"[%9q]", semver.New(1, 2, 3), `[  "1.2.3"]`},
+		{"%d", semver.New(1, 2, 3), "%!d(semver.V=1.2.3)"},
+	}
+	for _, tc := range tests {
+		if got := fmt.Sprintf(tc.format, tc.input); got != tc.want {
+			t.Errorf("Sprintf(%q, %v): got %q, want %q", tc.format, tc.input, got, tc.want)
+		}
+	}
+
+	vs := []semver.V{semver.New(1, 0, 0), semver.New(2, 0, 0)}
+	if got, want := fmt.Sprintf("%+v", vs), "[v1.0.0 v2.0.0]"; got != want {
+		t.Errorf("Sprintf(%%+v, %v): got %q, want %q", vs, got, want)
+	}
+}
+
+func TestSeries(t *testing.T) {
+	tests := []struct {
+		input, prefix, want string
+	}{
+		{"1.2.3", "", "1.2"},
+		{"1.2.3", "v", "v1.2"},
+		{"1.2.3-rc1+b", "v", "v1.2"},
+		{"10.0.9", "release-", "release-10.0"},
+	}
+	for _, tc := range tests {
+		v := mustParse(t, tc.input)
+		if got := v.Series(tc.prefix); got != tc.want {
+			t.Errorf("[%v].Series(%q): got %q, want %q", v, tc.prefix, got, tc.want)
+		}
+	}
+	if got := (semver.V{}).Series("v"); got != "v0.0" {
+		t.Errorf("Zero Series: got %q, want v0.0", got)
+	}
+}
+
+func TestCaretTildeRange(t *testing.T) {
+	tests := []struct {
+		input, caret, tilde string
+	}{
+		{"1.2.3", "^1.2.3", "~1.2.3"},
+		{"0.2.3", "^0.2.3", "~0.2.3"},
+		{"0.0.3", "^0.0.3", "~0.0.3"},
+		{"1.2.3-rc1+b", "^1.2.3", "~1.2.3"},
+	}
+	for _, tc := range tests {
+		v := mustParse(t, tc.input)
+		if got := v.CaretRange(); got != tc.caret {
+			t.Errorf("[%v].CaretRange(): got %q, want %q", v, got, tc.caret)
+		}
+		if got := v.TildeRange(); got != tc.tilde {
+			t.Errorf("[%v].TildeRange(): got %q, want %q", v, got, tc.tilde)
+		}
+	}
+	if got := (semver.V{}).CaretRange(); got != "^0.0.0" {
+		t.Errorf("Zero CaretRange: got %q, want ^0.0.0", got)
+	}
+}
+
+func TestMaven(t *testing.T) {
+	t.Run("ToMaven", func(t *testing.T) {
+		tests := []struct {
+			input, want string
+		}{
+			{"1.2.3", "1.2.3"},
+			{"1.2.3-SNAPSHOT", "1.2.3-SNAPSHOT"},
+			{"1.2.3-rc.1", "1.2.3-rc.1"},
+			{"1.2.3-rc.1+build.5", "1.2.3-rc.1"},
+		}
+		for _, tc := range tests {
+			v := mustParse(t, tc.input)
+			if got := semver.ToMaven(v); got != tc.want {
+				t.Errorf("ToMaven(%v): got %q, want %q", v, got, tc.want)
+			}
+		}
+	})
+	t.Run("FromMaven", func(t *testing.T) {
+		tests := []struct {
+			input, want, errText string
+		}{
+			{"1.2.3", "1.2.3", ""},
+			{"1.2.3-SNAPSHOT", "1.2.3-SNAPSHOT", ""},
+			{"1.2-SNAPSHOT", "1.2.0-SNAPSHOT", ""},
+			{"1.2.3.RELEASE", "1.2.3", ""},
+			{"5.3.release", "5.3.0", ""},
+			{"1.2.3-RELEASE", "1.2.3", ""},
+			{"1.2.3-4", "1.2.3+4", ""},
+			{"1.2.3-0042", "1.2.3+0042", ""},
+			{"1.2.3-alpha-1", "1.2.3-alpha-1", ""},
+			{"1.2.3-rc.2", "1.2.3-rc.2", ""},
+			{" 2 ", "2.0.0", ""},
+
+			{"1.2.3-", "", "empty release"},
+			{"1.2.3-a..b", "", "invalid qualifier"},
+			{"1.2.3-rc.01", "", "leading zeroes"},
+			{"1.2.3+x", "", "invalid maven version"},
+			{"1.2.x", "", "invalid patch"},
+			{"", "", "wrong length"},
+		}
+		for _, tc := range tests {
+			v, err := semver.FromMaven(tc.input)
+			if tc.errText != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errText) {
+					t.Errorf("FromMaven(%q): got (%v, %v), want error %q", tc.input, v, err, tc.errText)
+				}
+			} else if err != nil {
+				t.Errorf("FromMaven(%q): unexpected error: %v", tc.input, err)
+			} else if got := v.String(); got != tc.want {
+				t.Errorf("FromMaven(%q): got %q, want %q", tc.input, got, tc.want)
+			}
+		}
+	})
+}
+
+func TestModule(t *testing.T) {
+	t.Run("ToModule", func(t *testing.T) {
+		tests := []struct {
+			input, want string
+		}{
+			{"0.0.0", "v0.0.0"},
+			{"1.2.3", "v1.2.3"},
+			{"1.2.3-rc.1", "v1.2.3-rc.1"},
+			{"1.2.3-rc.1+build.5", "v1.2.3-rc.1"},
+			{"2.0.0+incompatible", "v2.0.0"},
+		}
+		for _, tc := range tests {
+			v := mustParse(t, tc.input)
+			if got := v.ToModule(); got != tc.want {
+				t.Errorf("[%v].ToModule(): got %q, want %q", v, got, tc.want)
+			}
+		}
+	})
+	t.Run("FromModule", func(t *testing.T) {
+		tests := []struct {
+			input, want, errText string
+		}{
+			{"v1", "1.0.0", ""},
+			{"v1.2", "1.2.0", ""},
+			{"v1.2.3", "1.2.3", ""},
+			{"v1.2.3-pre", "1.2.3-pre", ""},
+			{"v1.2.3-pre+meta", "1.2.3-pre+meta", ""},
+			{"v2.0.0+incompatible", "2.0.0+incompatible", ""},
+
+			{"", "", "missing v prefix"},
+			{"1.2.3", "", "missing v prefix"},
+			{"v", "", "invalid module version"},
+			{"v1.", "", "invalid module version"},
+			{"v01.2.3", "", "leading zeroes"},
+			{"v1.2-pre", "", "invalid module version"},
+			{"v1.2.3.4", "", "invalid module version"},
+			{"v1.2.3-01", "", "leading zeroes"},
+			{" v1.2.3", "", "missing v prefix"},
+		}
+		for _, tc := range tests {
+			v, err := semver.FromModule(tc.input)
+			if tc.errText != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errText) {
+					t.Errorf("FromModule(%q): got (%v, %v), want error %q", tc.input, v, err, tc.errText)
+				}
+			} else if err != nil {
+				t.Errorf("FromModule(%q): unexpected error: %v", tc.input, err)
+			} else if got := v.String(); got != tc.want {
+				t.Errorf("FromModule(%q): got %q, want %q", tc.input, got, tc.want)
+			}
+		}
+	})
+	t.Run("Order", func(t *testing.T) {
+		// This corpus is in ascending order as defined by x/mod/semver.Compare.
+		// Versions in the same group compare equal.
+		corpus := [][]string{
+			{"v0.0.0"},
+			{"v0.0.1"},
+			{"v0.1", "v0.1.0"},
+			{"v0.1.1"},
+			{"v1.0.0-0"},
+			{"v1.0.0-1"},
+			{"v1.0.0-2"},
+			{"v1.0.0-10"},
+			{"v1.0.0-alpha"},
+			{"v1.0.0-alpha.1"},
+			{"v1.0.0-alpha.beta"},
+			{"v1.0.0-beta"},
+			{"v1.0.0-beta.2"},
+			{"v1.0.0-beta.11"},
+			{"v1.0.0-rc.1"},
+			{"v1", "v1.0", "v1.0.0", "v1.0.0+build", "v1.0.0+other.5"},
+			{"v1.2.0-x.Y.0"},
+			{"v1.2.0-x.y.0"},
+			{"v1.2.0"},
+			{"v1.9.0"},
+			{"v1.10.0"},
+			{"v2.0.0+incompatible"},
+			{"v10.0.0"},
+			{"v99999999999999999999.0.0"},
+		}
+		for i, gi := range corpus {
+			for j, gj := range corpus {
+				want := cmp.Compare(i, j)
+				for _, a := range gi {
+					for _, b := range gj {
+						va, err := semver.FromModule(a)
+						if err != nil {
+							t.Fatalf("FromModule(%q): unexpected error: %v", a, err)
+						}
+						vb, err := semver.FromModule(b)
+						if err != nil {
+							t.Fatalf("FromModule(%q): unexpected error: %v", b, err)
+						}
+						if got := semver.Compare(va, vb); got != want {
+							t.Errorf("Compare(%q, %q): got %d, want %d", a, b, got, want)
+						}
+					}
+				}
+			}
+		}
+	})
+}
+
+func TestParseDescribe(t *testing.T) {
+	tests := []struct {
+		input, want, errText string
+	}{
+		{"v1.2.3", "1.2.3", ""},
+		{"1.2.3\n", "1.2.3", ""},
+		{"v1.2.3-5-gabc1234", "1.2.3+5.gabc1234", ""},
+		{"v1.2.3-0-gabc1234", "1.2.3+0.gabc1234", ""},
+		{"v1.2.3-5-gabc1234-dirty", "1.2.3+5.gabc1234.dirty", ""},
+		{"v1.2.3-dirty", "1.2.3+dirty", ""},
+		{"v1.2.3-rc.1-5-gabc1234", "1.2.3-rc.1+5.gabc1234", ""},
+		{"v1.2.3-rc.1+b.2-5-gabc1234", "1.2.3-rc.1+b.2.5.gabc1234", ""},
+		{"v1.2.3-rc1", "1.2.3-rc1", ""},
+		{"v1.2.3-5-gxyz", "1.2.3-5-gxyz", ""}, // not a hash, so part of the tag
+		{"v1.2-5-gabc1234", "1.2.0+5.gabc1234", ""},
+
+		{"", "", "invalid tag"},
+		{"-5-gabc1234", "", "invalid tag"},
+		{"release-5-gabc1234", "", "invalid tag"},
+		{"v1.x-5-gabc1234", "", "invalid tag"},
+	}
+	for _, tc := range tests {
+		v, err := semver.ParseDescribe(tc.input)
+		if tc.errText != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.errText) {
+				t.Errorf("ParseDescribe(%q): got (%v, %v), want error %q", tc.input, v, err, tc.errText)
+			}
+		} else if err != nil {
+			t.Errorf("ParseDescribe(%q): unexpected error: %v", tc.input, err)
+		} else if got := v.String(); got != tc.want {
+			t.Errorf("ParseDescribe(%q): got %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestPadPrerelease(t *testing.T) {
+	tests := []struct {
+		input  string
+		digits int
+		want   string
+	}{
+		{"1.0.0", 3, "1.0.0"},
+		{"1.0.0+5", 3, "1.0.0+5"},
+		{"1.0.0-rc.2", 3, "1.0.0-rc.002"},
+		{"1.0.0-rc.2", 0, "1.0.0-rc.2"},
+		{"1.0.0-rc.10", 2, "1.0.0-rc.10"},
+		{"1.0.0-rc.1234", 2, "1.0.0-rc.1234"},
+		{"1.0.0-rc2.3+b.4", 2, "1.0.0-rc2.03+b.4"},
+		{"1.0.0-1.beta.0", 3, "1.0.0-001.beta.000"},
+	}
+	for _, tc := range tests {
+		v := mustParse(t, tc.input)
+		if got := v.PadPrerelease(tc.digits); got != tc.want {
+			t.Errorf("[%v].PadPrerelease(%d): got %q, want %q", v, tc.digits, got, tc.want)
+		}
+	}
+}
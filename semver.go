@@ -42,6 +42,14 @@
 //
 // If using [V] values as map keys, consider using [V.Key].
 //
+// # Constraints
+//
+// A [Constraint] is a predicate on versions, such as ">=1.2.0 <2.0.0".
+// To check whether a version satisfies a constraint, use:
+//
+//	c, err := semver.ParseConstraint(">=1.2.0 <2.0.0")
+//	ok := c.Matches(v)
+//
 // [Semantic Version]: https://semver.org/
 package semver
 
@@ -49,6 +57,8 @@ import (
 	"cmp"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -68,6 +78,13 @@ func New(major, minor, patch int) V {
 	return V{major: mustItoa(major), minor: mustItoa(minor), patch: mustItoa(patch)}
 }
 
+// IsZero reports whether v is the zero value of [V], which has no fields set.
+// The zero value renders as "0.0.0", but is distinct from any version that
+// was explicitly constructed or parsed: New(0, 0, 0).IsZero() and
+// MustParse("0.0.0").IsZero() are both false. This allows the zero value to
+// serve as an "unset" marker, for example with the omitzero JSON tag option.
+func (v V) IsZero() bool { return v == V{} }
+
 // Before reports whether v is before w in version order.
 // See also [Compare].
 func (v V) Before(w V) bool { return Compare(v, w) < 0 }
@@ -76,6 +93,33 @@ func (v V) Before(w V) bool { return Compare(v, w) < 0 }
 // See also [Compare].
 func (v V) After(w V) bool { return Compare(v, w) > 0 }
 
+// Between reports whether v is in the half-open interval [lo, hi), that is,
+// whether lo ≤ v < hi in version order. The lower bound is inclusive and the
+// upper bound is exclusive, so that 1.2.3 is between 1.2.3 and 1.3.0, but
+// 1.3.0 is not. Note that 1.3.0-rc1 is between 1.2.3 and 1.3.0, since a
+// prerelease precedes its release. If lo ≥ hi, the interval is empty and
+// Between reports false. See also [Compare].
+func (v V) Between(lo, hi V) bool { return Compare(lo, v) <= 0 && Compare(v, hi) < 0 }
+
+// BetweenInclusive reports whether v is in the closed interval [lo, hi],
+// that is, whether lo ≤ v ≤ hi in version order. If lo > hi, the interval
+// is empty and BetweenInclusive reports false. See also [Compare].
+func (v V) BetweenInclusive(lo, hi V) bool { return Compare(lo, v) <= 0 && Compare(v, hi) <= 0 }
+
+// Clamp returns v constrained to the closed interval [lo, hi]: lo if v is
+// before lo, hi if v is after hi, and otherwise v itself. Comparison is as
+// for [Compare], so if v is equivalent to a bound but differs in its build
+// metadata, v is returned. If lo is after hi the interval is empty, and Clamp
+// returns lo regardless of v.
+func (v V) Clamp(lo, hi V) V {
+	if Compare(lo, hi) > 0 || Compare(v, lo) < 0 {
+		return lo
+	} else if Compare(v, hi) > 0 {
+		return hi
+	}
+	return v
+}
+
 // Equiv reports whether v and w are equivalent versions. Note that this is
 // distinct from equality, because semantic version comparison ignores build
 // metadata.
@@ -85,27 +129,178 @@ func (v V) Equiv(w V) bool { return v.Key() == w.Key() }
 // key or for equality comparison. This is equivalent to v.WithBuild("").
 func (v V) Key() V { v.build = ""; return v }
 
-// Major reports the major version as an int.
+// PrecedenceString returns the string representation of the parts of v that
+// affect its precedence order, namely the core version and release label.
+// Build metadata are omitted. This is equivalent to v.Key().String().
+func (v V) PrecedenceString() string { return v.Key().String() }
+
+// ContentKey returns a string representation of v in which the words of the
+// build metadata are sorted and duplicates removed, so that versions whose
+// build metadata contain the same words in a different order share the same
+// key: 1.2.3+a.b and 1.2.3+b.a have the same ContentKey, but 1.2.3+a.c does
+// not. This is stronger than [V.Key], which discards build metadata, and
+// weaker than comparing strings, which is sensitive to the order of words.
+func (v V) ContentKey() string {
+	words := strings.Split(v.build, ".")
+	slices.Sort(words)
+	v.build = strings.Join(slices.Compact(words), ".")
+	return v.String()
+}
+
+// WouldCollide reports whether a and b occupy the same slot in a map keyed
+// by [V.Key], that is, whether a.Key() == b.Key(). Versions that differ only
+// in their build metadata collide.
+func WouldCollide(a, b V) bool { return a.Key() == b.Key() }
+
+// MapKey is a comparable value that identifies a version up to equivalence.
+// Use [V.AsMapKey] to obtain the MapKey for a version.
+type MapKey struct{ key V }
+
+// AsMapKey returns a MapKey for v, for use as a map key in place of v itself.
+// Two versions that are equivalent (see [V.Equiv]) have equal map keys, even
+// if they differ in build metadata.
+func (v V) AsMapKey() MapKey {
+	k := v.Key()
+	k.major, k.minor, k.patch = cmp.Or(k.major, "0"), cmp.Or(k.minor, "0"), cmp.Or(k.patch, "0")
+	return MapKey{key: k}
+}
+
+// V returns the version identified by k. The result has no build metadata.
+func (k MapKey) V() V { return k.key }
+
+// String returns the string representation of the version identified by k.
+func (k MapKey) String() string { return k.key.String() }
+
+// Major reports the major version as an int. A major version too large to
+// represent as an int is reported as [math.MaxInt].
 func (v V) Major() int { return mustVal(v.major) }
 
-// Minor reports the minor version as an int.
+// Minor reports the minor version as an int. A minor version too large to
+// represent as an int is reported as [math.MaxInt].
 func (v V) Minor() int { return mustVal(v.minor) }
 
-// Patch reports the patch version as an int.
+// Patch reports the patch version as an int. A patch version too large to
+// represent as an int is reported as [math.MaxInt].
 func (v V) Patch() int { return mustVal(v.patch) }
 
+// IsPrerelease reports whether v is a prerelease, having a non-empty release
+// label. Build metadata do not affect the result.
+func (v V) IsPrerelease() bool { return v.release != "" }
+
+// IsStable reports whether v is a stable release, having major version ≥ 1
+// and no release label. Build metadata do not affect the result.
+func (v V) IsStable() bool { return v.release == "" && v.Major() >= 1 }
+
 // Add returns a copy of v with the specified offsets added to core versions.
 // Negative offsets are allowed. Offsets that would cause a version to become
-// negative set it to 0 instead.
+// negative set it to 0 instead. The sums are computed exactly, even for
+// versions that do not fit in an int.
 func (v V) Add(dmajor, dminor, dpatch int) V {
-	m, i, p := max(v.Major()+dmajor, 0), max(v.Minor()+dminor, 0), max(v.Patch()+dpatch, 0)
-	return v.WithCore(m, i, p)
+	v.major, v.minor, v.patch = addNum(v.major, dmajor), addNum(v.minor, dminor), addNum(v.patch, dpatch)
+	return v
+}
+
+// IncMajor returns the next major version after v, (major+1).0.0, with no
+// release or build metadata.
+func (v V) IncMajor() V { return V{major: incNum(v.major), minor: "0", patch: "0"} }
+
+// IncMinor returns the next minor version after v, major.(minor+1).0, with no
+// release or build metadata. For example, 1.2.3-rc1 ⇒ 1.3.0.
+func (v V) IncMinor() V { return V{major: cmp.Or(v.major, "0"), minor: incNum(v.minor), patch: "0"} }
+
+// IncPatch returns the next patch version after v, major.minor.(patch+1),
+// with no release or build metadata. For example, 1.2.3-rc1 ⇒ 1.2.4.
+func (v V) IncPatch() V {
+	return V{major: cmp.Or(v.major, "0"), minor: cmp.Or(v.minor, "0"), patch: incNum(v.patch)}
+}
+
+// A Level identifies one of the core version fields, for use with [V.Bump].
+type Level int
+
+// Constants defining the valid levels.
+const (
+	Major Level = iota + 1 // the major version
+	Minor                  // the minor version
+	Patch                  // the patch version
+)
+
+// String returns the lower-case name of the level, e.g. "minor".
+func (l Level) String() string {
+	if l >= Major && l <= Patch {
+		return coreLabels[l-Major]
+	}
+	return fmt.Sprintf("Level(%d)", int(l))
+}
+
+// ParseLevel returns the [Level] named by s, which is one of "major", "minor",
+// or "patch" without regard to case. It reports an error for any other input.
+func ParseLevel(s string) (Level, error) {
+	for i, label := range coreLabels {
+		if strings.EqualFold(s, label) {
+			return Major + Level(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid level %q", s)
+}
+
+// Bump returns the next version after v at the specified level, as
+// [V.IncMajor], [V.IncMinor], or [V.IncPatch]. It panics if level is not one
+// of [Major], [Minor], or [Patch].
+func (v V) Bump(level Level) V {
+	switch level {
+	case Major:
+		return v.IncMajor()
+	case Minor:
+		return v.IncMinor()
+	case Patch:
+		return v.IncPatch()
+	}
+	panic(fmt.Sprintf("invalid level %v", level))
+}
+
+// ErrNotIncreasing is reported by [ClassifyChange] when the new version does
+// not follow the old one.
+var ErrNotIncreasing = errors.New("version does not increase")
+
+// ClassifyChange reports the [Level] of the change from old to new, which is
+// the first core field in which they differ: [Major] for 1.2.3 ⇒ 2.0.0,
+// [Minor] for 1.2.3 ⇒ 1.3.0, and [Patch] for 1.2.3 ⇒ 1.2.4. It reports an
+// error wrapping [ErrNotIncreasing] if new does not follow old, that is if
+// Compare(old, new) >= 0.
+//
+// Release labels are considered only when the core versions are equal. A
+// change between releases of the same core version, for example 1.2.3-rc.1 ⇒
+// 1.2.3-rc.2 or the finalization 1.2.3-rc.2 ⇒ 1.2.3, is classified as [Patch],
+// the smallest level. A change that crosses a core version is classified by
+// the core alone, so 1.2.3 ⇒ 2.0.0-rc.1 and 1.9.0-rc.1 ⇒ 2.0.0 are both
+// [Major]. Build metadata are ignored.
+func ClassifyChange(old, new V) (Level, error) {
+	switch {
+	case Compare(old, new) >= 0:
+		return 0, fmt.Errorf("version %v does not follow %v: %w", new, old, ErrNotIncreasing)
+	case compareNum(old.major, new.major) != 0:
+		return Major, nil
+	case compareNum(old.minor, new.minor) != 0:
+		return Minor, nil
+	default:
+		return Patch, nil
+	}
 }
 
 // Core returns a copy of v with its release and build metadata cleared,
 // corresponding to the "core" version ID (major.minor.patch).
 func (v V) Core() V { v.release = ""; v.build = ""; return v }
 
+// MajorMinor returns a copy of v truncated to its major and minor versions,
+// with the patch version set to 0 and the release and build metadata cleared.
+// For example, 1.2.3-rc.1+b ⇒ 1.2.0. This is intentionally not the same as
+// [V.Core], which preserves the patch version: all the patches of a release
+// line share the same MajorMinor, so it is a useful key for [GroupBy].
+// To render the "major.minor" string directly, use [V.Series].
+func (v V) MajorMinor() V {
+	return V{major: cmp.Or(v.major, "0"), minor: cmp.Or(v.minor, "0"), patch: "0"}
+}
+
 // WithCore returns a copy of v with its core version (major.minor.patch) set.
 // For any argument < 0, the corresponding version is copied unmodified from v.
 func (v V) WithCore(major, minor, patch int) V {
@@ -121,10 +316,141 @@ func (v V) WithCore(major, minor, patch int) V {
 	return v
 }
 
+// WithMajor returns a copy of v with its major version set to major.
+// The minor and patch versions, release, and build metadata are unchanged.
+// WithMajor will panic if major < 0.
+func (v V) WithMajor(major int) V { v.major = mustItoa(major); return v }
+
+// WithMinor returns a copy of v with its minor version set to minor.
+// The major and patch versions, release, and build metadata are unchanged.
+// WithMinor will panic if minor < 0.
+func (v V) WithMinor(minor int) V { v.minor = mustItoa(minor); return v }
+
+// WithPatch returns a copy of v with its patch version set to patch.
+// The major and minor versions, release, and build metadata are unchanged.
+// WithPatch will panic if patch < 0.
+func (v V) WithPatch(patch int) V { v.patch = mustItoa(patch); return v }
+
+// NextInWorkflow returns the next version of v in a workflow where each
+// release is preceded by a sequence of numbered prereleases with the given
+// label. The transitions are:
+//
+//   - If v is stable (has no release), the patch version is incremented and a
+//     new prerelease sequence is started: 1.2.3 ⇒ 1.2.4-label.1
+//   - If v is a prerelease whose release is label.N for an integer N, the
+//     counter is incremented: 1.2.4-label.1 ⇒ 1.2.4-label.2
+//   - If v is any other prerelease, a new sequence for label is started at
+//     the same core version: 1.2.4-beta ⇒ 1.2.4-label.1
+//
+// The result follows v in precedence, except when a new sequence is started
+// at the same core version and label.1 sorts before the existing release, as
+// in 1.2.4-rc.1 ⇒ 1.2.4-beta.1 or 1.2.4-rc.x ⇒ 1.2.4-rc.1; callers who need
+// to move forward should check the result with [V.After].
+// Build metadata are discarded in all cases.
+// NextInWorkflow will panic if label is empty or is not a valid release.
+func (v V) NextInWorkflow(label string) V {
+	if label == "" || checkRelease(label) != nil {
+		panic(fmt.Sprintf("invalid workflow label %q", label))
+	}
+	v.build = ""
+	if v.release == "" {
+		return v.IncPatch().WithRelease(label + ".1")
+	}
+	if tail, ok := strings.CutPrefix(v.release, label+"."); ok {
+		if _, ok := isNum(tail); ok && tail != "" {
+			return v.WithRelease(label + "." + incNum(tail))
+		}
+	}
+	return v.WithRelease(label + ".1")
+}
+
+// NextPrerelease returns the next prerelease of v, advancing the final
+// identifier of its release label. The transitions are:
+//
+//   - If the last word of the release is numeric, it is incremented:
+//     1.2.3-rc.1 ⇒ 1.2.3-rc.2, and 1.2.3-4 ⇒ 1.2.3-5
+//   - If the release is non-empty and its last word is not numeric, a new
+//     numeric word is appended: 1.2.3-rc ⇒ 1.2.3-rc.1, 1.2.3-rc1 ⇒ 1.2.3-rc1.1
+//   - If v is stable (has no release), the patch version is incremented and
+//     the release is set to "1": 1.2.3 ⇒ 1.2.4-1
+//
+// In all cases the result follows v in precedence. Build metadata are
+// discarded. To move to a differently-labeled phase, such as from alpha to
+// beta, use [V.NextInWorkflow] or [V.WithRelease].
+func (v V) NextPrerelease() V {
+	v.build = ""
+	if v.release == "" {
+		return v.IncPatch().WithRelease("1")
+	}
+	head, tail := "", v.release
+	if i := strings.LastIndexByte(v.release, '.'); i >= 0 {
+		head, tail = v.release[:i+1], v.release[i+1:]
+	}
+	if _, ok := isNum(tail); ok {
+		v.release = head + incNum(tail)
+	} else {
+		v.release += ".1"
+	}
+	return v
+}
+
+// Finalize returns a copy of v with its release label removed, giving the
+// stable version of the same core: 1.2.3-rc.2 ⇒ 1.2.3. If v is already
+// stable, Finalize returns v unchanged. Build metadata are preserved; use
+// [V.Core] to discard them as well.
+func (v V) Finalize() V { v.release = ""; return v }
+
+// Compatible reports whether w is a compatible replacement for v, under the
+// caret (^) relation: w must not be before v, and
+//
+//   - if v.Major() >= 1, w must have the same major version as v, so that
+//     1.2.3 admits 1.2.4 and 1.9.0 but not 2.0.0; or
+//   - if v.Major() == 0, w must have the same major AND minor versions as v,
+//     so that 0.2.3 admits 0.2.9 but not 0.3.0.
+//
+// The 0.x case reflects the convention that any minor change to an initial
+// development version may be breaking. No further special case is made for
+// 0.0.x versions, so 0.0.3 admits 0.0.4, whereas the caret constraint "^0.0.3"
+// admits only 0.0.3. Also unlike a [Constraint], Compatible does not treat
+// prerelease versions specially: 1.2.3 is compatible with 1.3.0-rc.1.
+// Build metadata are ignored.
+func (v V) Compatible(w V) bool { return isCompatible(v, w) }
+
+// CompatibleWithAny returns the first element of vs that is compatible with
+// v, and reports whether one was found. Compatibility is as for
+// [V.Compatible].
+func (v V) CompatibleWithAny(vs []V) (V, bool) {
+	for _, w := range vs {
+		if isCompatible(v, w) {
+			return w, true
+		}
+	}
+	return V{}, false
+}
+
+// isCompatible reports whether w is compatible with v, as defined by the
+// caret (^) relation: w ≥ v and v, w share their major version, or for
+// v.Major() == 0, their major and minor versions.
+func isCompatible(v, w V) bool {
+	if compareNum(v.major, w.major) != 0 || (v.Major() == 0 && compareNum(v.minor, w.minor) != 0) {
+		return false
+	}
+	return !w.Before(v)
+}
+
 // Release reports the release string, if present.
 // The resulting string does not include the "-" prefix.
 func (v V) Release() string { return v.release }
 
+// WithReleaseParts returns a copy of v with its release label set to the
+// given parts joined by ".", as [V.WithRelease]. Empty parts are discarded,
+// and if there are no non-empty parts, the result has no release label.
+func (v V) WithReleaseParts(parts ...string) V { return v.WithRelease(strings.Join(parts, ".")) }
+
+// ReleaseParts returns the dot-separated words of the release label of v, or
+// nil if v has no release label. The result is a new slice on each call.
+func (v V) ReleaseParts() []string { return splitWords(v.release) }
+
 // WithRelease returns a copy of v with its release ID set.
 // If id == "", the resulting version has no release ID.
 func (v V) WithRelease(id string) V { v.release = joinCleanWords(id); return v }
@@ -133,10 +459,65 @@ func (v V) WithRelease(id string) V { v.release = joinCleanWords(id); return v }
 // The resulting string does not include the "+" prefix.
 func (v V) Build() string { return v.build }
 
+// WithBuildParts returns a copy of v with its build metadata set to the given
+// parts joined by ".", as [V.WithBuild]. Empty parts are discarded, and if
+// there are no non-empty parts, the result has no build metadata.
+func (v V) WithBuildParts(parts ...string) V { return v.WithBuild(strings.Join(parts, ".")) }
+
+// BuildParts returns the dot-separated words of the build metadata of v, or
+// nil if v has no build metadata. The result is a new slice on each call.
+func (v V) BuildParts() []string { return splitWords(v.build) }
+
+// BuildField treats the build metadata of v as a sequence of alternating key
+// and value words, and reports the value associated with the first
+// occurrence of key, if any. For example, if the build metadata are
+// "build.123.sha.abc", then BuildField("sha") returns ("abc", true).
+// If the build metadata have an odd number of words, BuildField reports
+// ("", false) for every key.
+func (v V) BuildField(key string) (string, bool) {
+	if strings.Count(v.build, ".")%2 == 0 {
+		return "", false // empty, or an odd number of words
+	}
+	for s := v.build; s != ""; {
+		k, rest := cutDotWord(s)
+		val, rest := cutDotWord(rest)
+		if k == key {
+			return val, true
+		}
+		s = rest
+	}
+	return "", false
+}
+
 // WithBuild returns a copy of v with its build metadata set.
 // If meta == "", the resulting version has no build metadata.
 func (v V) WithBuild(meta string) V { v.build = joinCleanWords(meta); return v }
 
+// MergeBuild returns a copy of v whose build metadata are the words of the
+// given parts, in order. Empty words are discarded, and if a word occurs more
+// than once only its first occurrence is kept. Any existing build metadata
+// of v are replaced. For example:
+//
+//	v.MergeBuild("linux.amd64", "git.abc123", "amd64.2024")
+//
+// has build metadata "linux.amd64.git.abc123.2024".
+func (v V) MergeBuild(parts ...string) V {
+	var words []string
+	seen := make(map[string]bool)
+	for _, p := range parts {
+		for p != "" {
+			w, rest := cutDotWord(p)
+			if w != "" && !seen[w] {
+				seen[w] = true
+				words = append(words, w)
+			}
+			p = rest
+		}
+	}
+	v.build = strings.Join(words, ".")
+	return v
+}
+
 // String returns the complete canonical string representation of v.
 func (v V) String() string {
 	var sb strings.Builder
@@ -151,6 +532,41 @@ func (v V) String() string {
 	return sb.String()
 }
 
+// StringV returns the string representation of v with a "v" prefix, as used
+// by Go module versions and many version control tags: "v1.2.3". The prefix
+// is purely cosmetic, and does not affect comparison. [Clean], [ParseClean],
+// and [V.UnmarshalText] accept the prefix on input.
+func (v V) StringV() string { return "v" + v.String() }
+
+// RoundTrips reports whether formatting v as a string and parsing the result
+// with [Parse] yields a version identical to v, including build metadata.
+// This holds for every version returned by Parse, but may fail for versions
+// whose labels were set to invalid values by [V.WithRelease] or [V.WithBuild].
+func (v V) RoundTrips() bool {
+	w, err := Parse(v.String())
+	return err == nil && w.AsMapKey() == v.AsMapKey() && w.build == v.build
+}
+
+// Canonical returns the canonical string representation of v. For any
+// version returned by [Parse], this is the same as [V.String]. A version
+// whose release label was set by [V.WithRelease] may contain numeric words
+// with leading zeroes, which are not valid; Canonical removes them, so that
+// 1.2.3-rc.01 is rendered as "1.2.3-rc.1". The precedence of the result is
+// unchanged. Invalid characters in release or build labels are not repaired.
+func (v V) Canonical() string {
+	if v.release == "" {
+		return v.String()
+	}
+	words := splitWords(v.release)
+	for i, w := range words {
+		if _, ok := isNum(w); ok {
+			words[i], _ = trimLeadingZeroes(w)
+		}
+	}
+	v.release = strings.Join(words, ".")
+	return v.String()
+}
+
 // MarshalText implements the [encoding.TextMarshaler] interface.
 // This implementation never reports an error, and returns the same
 // text as [V.String].
@@ -173,24 +589,29 @@ func (v *V) UnmarshalText(text []byte) error {
 //
 // Semantic versions are ordered lexicographically by major, minor, patch, and
 // pre-release labels. The core major, minor, and patch labels are compared
-// numerically, with smaller values ordered earlier.
+// numerically, with smaller values ordered earlier. Numbers of any magnitude
+// are compared correctly, even if they are too large to represent as an int.
 //
 // Pre-release labels are split into non-empty words separated by period (".")
 // and compared lexicographically. Words comprising only digits are compared
 // numerically; otherwise they are compared lexicographically as strings.
+// A word comprising only digits precedes one that does not.
 // When the two lists are of unequal length and the shorter list is equal to a
-// prefix of the longer one, the longer list is ordered earlier.
+// prefix of the longer one, the shorter list is ordered earlier.
 //
 // Build metadata are ignored for comparison, so if v1 and v2 are equal apart
 // from their build metadata, Compare(v1, v2) reports 0.
+//
+// This ordering agrees with golang.org/x/mod/semver.Compare for versions
+// converted by [FromModule].
 func Compare(v1, v2 V) int {
-	if c := cmp.Compare(mustVal(v1.major), mustVal(v2.major)); c != 0 {
+	if c := compareNum(v1.major, v2.major); c != 0 {
 		return c
 	}
-	if c := cmp.Compare(mustVal(v1.minor), mustVal(v2.minor)); c != 0 {
+	if c := compareNum(v1.minor, v2.minor); c != 0 {
 		return c
 	}
-	if c := cmp.Compare(mustVal(v1.patch), mustVal(v2.patch)); c != 0 {
+	if c := compareNum(v1.patch, v2.patch); c != 0 {
 		return c
 	}
 	// A non-empty release precedes an empty one.
@@ -204,18 +625,95 @@ func Compare(v1, v2 V) int {
 	// N.B. Build metadata are not considered for comparisons.
 }
 
+// CompareCore compares v1 and v2 by their core versions (major.minor.patch)
+// only, ignoring release labels and build metadata. Thus 1.2.3-rc1 and 1.2.3
+// compare equal, but 1.2.3 is before 1.2.4-rc1.
+// It returns -1 if v1 < v2, 0 if v1 == v2, and +1 if v1 > v2.
+//
+// This differs from [Compare], which orders a prerelease before the stable
+// release of the same core, so that Compare(1.2.3-rc1, 1.2.3) = -1.
+// CompareCore(v1, v2) is equivalent to Compare(v1.Core(), v2.Core()).
+func CompareCore(v1, v2 V) int {
+	if c := compareNum(v1.major, v2.major); c != 0 {
+		return c
+	} else if c := compareNum(v1.minor, v2.minor); c != 0 {
+		return c
+	}
+	return compareNum(v1.patch, v2.patch)
+}
+
+// CompareMinor compares v1 and v2 by their major and minor versions only,
+// ignoring patch versions, release labels, and build metadata. Thus 1.2.3 and
+// 1.2.9-rc1 compare equal, but 1.2.9 is before 1.3.0.
+// It returns -1 if v1 < v2, 0 if v1 == v2, and +1 if v1 > v2.
+func CompareMinor(v1, v2 V) int {
+	if c := compareNum(v1.major, v2.major); c != 0 {
+		return c
+	}
+	return compareNum(v1.minor, v2.minor)
+}
+
+// CompareFull compares v1 and v2 as [Compare], but if they are equivalent
+// breaks the tie by comparing their build metadata word by word, as for
+// release labels. A version with no build metadata precedes one with build
+// metadata. Thus 1.2.3 < 1.2.3+2 < 1.2.3+10 < 1.2.3+a.
+//
+// Unlike Compare, CompareFull is a total order: it reports 0 only if v1 and
+// v2 have the same precedence and the same build metadata. It is suitable
+// for deterministic sorting and deduplication, but it does not reflect
+// semantic version precedence, which ignores build metadata.
+func CompareFull(v1, v2 V) int {
+	if c := Compare(v1, v2); c != 0 {
+		return c
+	} else if c := compareWords(v1.build, v2.build); c != 0 {
+		return c
+	}
+	return cmp.Compare(v1.build, v2.build) // e.g., "07" vs. "7"
+}
+
 // CompareStrings compares s1 and s2 in standard semantic version order.
 // The strings are cleaned (see [Clean]) before comparison.
 // It returns -1 if s1 < s2, 0 if s1 == s2, and +1 if s1 > s2.
 // If either string is not a valid semver after cleaning, the two strings are
-// compared in ordinary lexicographic order.
+// compared in ordinary lexicographic order. Use [CompareStringsValid] to
+// detect this case.
 func CompareStrings(s1, s2 string) int {
+	c, _ := CompareStringsValid(s1, s2)
+	return c
+}
+
+// CompareStringsValid compares s1 and s2 as [CompareStrings], and also
+// reports whether both strings are valid semver after cleaning. If ok is
+// false, the comparison is the lexicographic fallback, which is consistent
+// but does not reflect version order. For example, "12 angry cats" compares
+// before "6.2.4", because '1' < '6'.
+func CompareStringsValid(s1, s2 string) (_ int, ok bool) {
 	if v1, _, err := parseClean(s1); err == nil {
 		if v2, _, err := parseClean(s2); err == nil {
-			return Compare(v1, v2)
+			return Compare(v1, v2), true
 		}
 	}
-	return cmp.Compare(s1, s2)
+	return cmp.Compare(s1, s2), false
+}
+
+// IsLatestKeyword reports whether s is the keyword "latest", ignoring case
+// and surrounding whitespace.
+func IsLatestKeyword(s string) bool { return strings.EqualFold(strings.TrimSpace(s), "latest") }
+
+// CompareStringsLatest compares s1 and s2 as [CompareStrings], except that a
+// string recognized by [IsLatestKeyword] is ordered after all other strings,
+// and two such strings compare as equal.
+func CompareStringsLatest(s1, s2 string) int {
+	l1, l2 := IsLatestKeyword(s1), IsLatestKeyword(s2)
+	switch {
+	case l1 && l2:
+		return 0
+	case l1:
+		return 1
+	case l2:
+		return -1
+	}
+	return CompareStrings(s1, s2)
 }
 
 // MustParse returns the [V] represented by s, or panics.  This is intended for
@@ -231,53 +729,323 @@ func MustParse(s string) V {
 // IsValid reports whether s is a valid semver string.
 func IsValid(s string) bool { _, err := Parse(s); return err == nil }
 
+// IsCanonical reports whether s is a valid semver string in canonical form,
+// that is, whether s parses without error and v.String() == s for the
+// resulting v. Parse does not normalize its input, so at present any string
+// accepted by Parse is canonical; strings such as "v1.2", "1.02.3", or
+// "1.2.3-a..b" that are valid only after cleaning (see [Clean]) are not.
+// Linters can use this to flag versions that should be rewritten, for
+// example to the result of Clean.
+func IsCanonical(s string) bool {
+	v, err := Parse(s)
+	return err == nil && v.String() == s
+}
+
+// RequireBuild reports an error if v has no build metadata, otherwise nil.
+// It checks only that build metadata are present, not their content.
+func RequireBuild(v V) error {
+	if v.build == "" {
+		return errMissingBuild
+	}
+	return nil
+}
+
+// ParseRequireBuild returns the [V] represented by s, as [Parse], but reports
+// an error if the resulting version has no build metadata (see [RequireBuild]).
+func ParseRequireBuild(s string) (V, error) {
+	v, err := Parse(s)
+	if err == nil {
+		err = RequireBuild(v)
+	}
+	if err != nil {
+		return V{}, err
+	}
+	return v, nil
+}
+
+// IsPublishable reports whether v is a clean public release, having no release
+// label or build metadata. To permit build metadata, use
+// [IsPublishableAllowBuild]. See also [V.PublishErrors].
+func IsPublishable(v V) bool { return len(v.publishErrors(false)) == 0 }
+
+// IsPublishableAllowBuild reports whether v is a clean public release, as
+// [IsPublishable], except that build metadata are permitted.
+func IsPublishableAllowBuild(v V) bool { return len(v.publishErrors(true)) == 0 }
+
+// PublishErrors returns a list of reasons why v is not publishable (see
+// [IsPublishable]), or nil if v is publishable. In addition to the presence of
+// a release label or build metadata, this reports an error if v does not
+// format as a valid version string, as may happen if its labels were set to
+// invalid values.
+func (v V) PublishErrors() []error { return v.publishErrors(false) }
+
+// publishErrors implements [V.PublishErrors]. If allowBuild is true, build
+// metadata are not reported, though they must still be valid.
+func (v V) publishErrors(allowBuild bool) []error {
+	var errs []error
+	if _, err := Parse(v.String()); err != nil {
+		errs = append(errs, err)
+	}
+	if v.release != "" {
+		errs = append(errs, fmt.Errorf("has prerelease %s", v.release))
+	}
+	if v.build != "" && !allowBuild {
+		errs = append(errs, fmt.Errorf("has build metadata %s", v.build))
+	}
+	return errs
+}
+
+// HasLeadingZeros reports whether s contains a numeric identifier with an
+// illegal leading zero, either in the core version or in the release label.
+// Leading zeroes are permitted in build metadata, so they are not reported.
+// An optional "v" prefix is ignored, as in "v01.2.3".
+// HasLeadingZeros does not check whether s is otherwise valid.
+func HasLeadingZeros(s string) bool {
+	core, release, _, _, _ := splitReleaseBuild(strings.TrimPrefix(s, "v"))
+	return hasLeadingZeroWord(core) || hasLeadingZeroWord(release)
+}
+
+// hasLeadingZeroWord reports whether any dot-separated word of s consists of
+// more than one digit with a leading zero.
+func hasLeadingZeroWord(s string) bool {
+	for s != "" {
+		w, rest := cutDotWord(s)
+		if _, ok := isNum(w); ok && len(w) > 1 && w[0] == '0' {
+			return true
+		}
+		s = rest
+	}
+	return false
+}
+
 // Parse returns the [V] represented by s. It reports an error if s is not a
 // valid semantic version string. On success, Parse does not allocate.
 //
 // Parse is strict about the semver grammar, and does not whitespace, a "v"
 // prefix, or "partial" versions like "1.2". Use [Clean] or [ParseClean] to
 // handle version strings with those properties.
-func Parse(s string) (V, error) {
+//
+// Errors reported by Parse can be converted to a [*ParseError] with
+// [errors.As], to obtain the field and byte offset of the problem, and
+// checked with [errors.Is] against [ErrLeadingZero] and similar values.
+func Parse(s string) (V, error) { return parseMarkers(s, '-', '+') }
+
+// ParseBytes returns the [V] represented by b, as [Parse]. The results,
+// including errors, are identical to Parse(string(b)).
+//
+// Because a [V] must not share memory with b, which the caller may modify,
+// ParseBytes copies b exactly once; it does not otherwise allocate on success,
+// and does not retain b.
+func ParseBytes(b []byte) (V, error) { return Parse(string(b)) }
+
+// ParseMarkers returns the [V] represented by s, as [Parse], but using
+// preMark and buildMark in place of "-" and "+" respectively to mark the
+// release and build labels. The contents of the labels are checked using the
+// standard rules, and the resulting version formats in standard notation.
+// For example:
+//
+//	v, err := ParseMarkers("1.2.3~rc1_b5", '~', '_')
+//	v.String() // "1.2.3-rc1+b5"
+//
+// The markers must differ, and must not be ASCII digits, letters, or ".".
+// ParseMarkers(s, '-', '+') is equivalent to Parse(s).
+func ParseMarkers(s string, preMark, buildMark byte) (V, error) {
+	if preMark == buildMark || !isMarker(preMark) || !isMarker(buildMark) {
+		return V{}, errInvalidMarker
+	}
+	return parseMarkers(s, preMark, buildMark)
+}
+
+// isMarker reports whether b may be used as a label marker.
+func isMarker(b byte) bool { return b != '.' && (b == '-' || !isWord(string(b))) }
+
+// parseMarkers implements [Parse] using the specified label markers.
+func parseMarkers(s string, preMark, buildMark byte) (V, error) {
 	// Grammar: https://semver.org/#backusnaur-form-grammar-for-valid-semver-versions
 
 	// Check for release and build labels.
-	s, release, build, hasRelease, hasBuild := splitReleaseBuild(s)
+	s, release, build, hasRelease, hasBuild := splitMarkers(s, preMark, buildMark)
 
 	// Parse the base version: major '.' minor '.' patch
 	ps, err := split3(s)
 	if err != nil {
-		return V{}, err
-	}
-	v := V{major: ps[0], minor: ps[1], patch: ps[2]}
-	if err := checkVNum(v.major); err != nil {
-		return V{}, invalidThingError{"major", v.major, err}
+		return V{}, lengthError(s, ps, err.(countError))
 	}
-	if err := checkVNum(v.minor); err != nil {
-		return V{}, invalidThingError{"minor", v.minor, err}
-	}
-	if err := checkVNum(v.patch); err != nil {
-		return V{}, invalidThingError{"patch", v.patch, err}
+	pos := 0
+	for i, p := range ps {
+		if err := checkVNum(p); err != nil {
+			return V{}, newFieldError(coreLabels[i], p, pos, err)
+		}
+		pos += len(p) + 1
 	}
+	v := V{major: ps[0], minor: ps[1], patch: ps[2]}
 
 	if hasRelease {
 		if release == "" {
-			return V{}, errEmptyRelease
-		} else if err := checkWords(release); err != nil {
-			return V{}, invalidThingError{"release", release, err}
+			return V{}, fieldError{field: "release", offset: pos, err: ErrEmptyRelease, bare: true}
+		} else if err := checkRelease(release); err != nil {
+			return V{}, newFieldError("release", release, pos, err)
 		}
 		v.release = release
+		pos += len(release) + 1
 	}
 	if hasBuild {
 		if build == "" {
-			return V{}, errEmptyBuild
+			return V{}, fieldError{field: "build", offset: pos, err: ErrEmptyBuild, bare: true}
 		} else if err := checkWords(build); err != nil {
-			return V{}, invalidThingError{"build", build, err}
+			return V{}, newFieldError("build", build, pos, err)
 		}
 		v.build = build
 	}
 	return v, nil
 }
 
+// A ParseOption adjusts the inputs accepted by [ParseWith].
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	vPrefix bool // allow a "v" prefix
+	partial bool // allow omitted minor and patch versions
+	noBuild bool // reject build metadata
+}
+
+// AllowVPrefix is a [ParseOption] that permits s to have a "v" prefix, as in
+// "v1.2.3". The prefix is discarded.
+func AllowVPrefix() ParseOption { return func(o *parseOptions) { o.vPrefix = true } }
+
+// AllowPartial is a [ParseOption] that permits s to omit the minor version,
+// or the patch version, or both, as in "1.2" or "1-rc.1". The omitted
+// versions are taken to be 0.
+func AllowPartial() ParseOption { return func(o *parseOptions) { o.partial = true } }
+
+// DisallowBuild is a [ParseOption] that rejects a version with build
+// metadata, as in "1.2.3+x".
+func DisallowBuild() ParseOption { return func(o *parseOptions) { o.noBuild = true } }
+
+// ParseWith returns the [V] represented by s, as [Parse], with the grammar
+// adjusted by the specified options. With no options, ParseWith is identical
+// to Parse. Unlike [ParseClean], ParseWith does not otherwise repair its
+// input: whitespace, leading zeroes, and empty labels are still rejected.
+//
+// As for Parse, errors from ParseWith can be converted to a [*ParseError],
+// whose offsets are relative to s. Build metadata rejected by [DisallowBuild]
+// are reported as [ErrBuildMetadata].
+func ParseWith(s string, opts ...ParseOption) (V, error) {
+	var o parseOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	var shift int
+	if o.vPrefix {
+		if t, ok := strings.CutPrefix(s, "v"); ok {
+			s, shift = t, 1
+		}
+	}
+	var at, added int // where and how many bytes were inserted into s
+	if o.partial {
+		i := strings.IndexAny(s, "-+")
+		if i < 0 {
+			i = len(s)
+		}
+		if i > 0 {
+			if n := strings.Count(s[:i], "."); n < 2 {
+				fill := strings.Repeat(".0", 2-n)
+				s = s[:i] + fill + s[i:]
+				at, added = i, len(fill)
+			}
+		}
+	}
+	v, err := Parse(s)
+	if err == nil && o.noBuild && v.build != "" {
+		pos := len(s) - len(v.build)
+		err = fieldError{field: "build", text: v.build, offset: pos, err: ErrBuildMetadata}
+	}
+	if err != nil {
+		if fe, ok := err.(fieldError); ok {
+			if fe.offset > at {
+				fe.offset = max(fe.offset-added, at)
+			}
+			fe.offset += shift
+			err = fe
+		}
+		return V{}, err
+	}
+	return v, nil
+}
+
+// Validate checks whether s is a valid semantic version string, as [Parse]
+// does, but reports all the problems it finds rather than only the first.
+// If s is valid, Validate returns nil. Otherwise, each error in the result
+// describes one problem, in order of its position in s. As for Parse, each
+// error can be converted to a [*ParseError] with [errors.As].
+//
+// If the core version does not have exactly three fields, Validate reports
+// that, and also checks any major and minor fields that are present.
+func Validate(s string) []error {
+	core, release, build, hasRelease, hasBuild := splitMarkers(s, '-', '+')
+
+	var errs []error
+	ps, err := split3(core)
+	nc := 3
+	if err != nil {
+		nc = min(int(err.(countError)), 2)
+	}
+	pos := 0
+	for i, p := range ps[:nc] {
+		if err := checkVNum(p); err != nil {
+			errs = append(errs, newFieldError(coreLabels[i], p, pos, err))
+		}
+		pos += len(p) + 1
+	}
+	if err != nil {
+		errs = append(errs, lengthError(core, ps, err.(countError)))
+	}
+
+	pos = len(core) + 1
+	if hasRelease {
+		if release == "" {
+			errs = append(errs, fieldError{field: "release", offset: pos, err: ErrEmptyRelease, bare: true})
+		}
+		errs = appendWordErrors(errs, "release", release, pos, true)
+		pos += len(release) + 1
+	}
+	if hasBuild {
+		if build == "" {
+			errs = append(errs, fieldError{field: "build", offset: pos, err: ErrEmptyBuild, bare: true})
+		}
+		errs = appendWordErrors(errs, "build", build, pos, false)
+	}
+	return errs
+}
+
+// appendWordErrors appends to errs an error for each invalid word of the
+// specified text of field, starting at offset pos of the input, and returns
+// the updated slice. If numeric is true, numeric words must not have leading
+// zeroes, as in a release label.
+func appendWordErrors(errs []error, field, text string, pos int, numeric bool) []error {
+	if text == "" {
+		return errs
+	}
+	for i, s := 1, text; ; i++ {
+		w, rest, more := strings.Cut(s, ".")
+		var err error
+		if w == "" {
+			err = emptyWordPosError(i)
+		} else if !isWord(w) {
+			err = invalidCharPosError(i)
+		} else if _, ok := isNum(w); ok && numeric && len(w) > 1 && w[0] == '0' {
+			err = leadingZeroPosError(i)
+		}
+		if err != nil {
+			errs = append(errs, newFieldError(field, text, pos, err))
+		}
+		if !more {
+			return errs
+		}
+		s = rest
+	}
+}
+
 // ParseClean returns the [V] represented by s. It reports an error if s is not
 // a valid semantic version string after cleaning (as per [Clean]).
 func ParseClean(s string) (V, error) {
@@ -285,6 +1053,125 @@ func ParseClean(s string) (V, error) {
 	return v, err
 }
 
+// Coerce extracts a version from arbitrary text, and reports whether one was
+// found. It is intended for untrusted or messy input, such as tool output or
+// file names, where [Clean] and [ParseClean] are too strict. For example:
+//
+//	"release-1.2 (final)"  ⇒  1.2.0
+//	"v1.2.3_amd64"         ⇒  1.2.3
+//	"foo-1.2.3-rc.1+b5.x"  ⇒  1.2.3-rc.1+b5.x
+//
+// Coerce finds the first sequence of decimal digits in s, and takes it and up
+// to two following dot-separated sequences of digits as the major, minor,
+// and patch versions. Missing minor and patch versions are set to 0, and
+// leading zeroes are removed. If all three core fields are present, a
+// release label ("-") and build metadata ("+") directly following them are
+// included if they are valid; otherwise they are discarded.
+//
+// Coerce is lossy, and ignores text before and after the version. Note that
+// it will find digits wherever they occur, so "x86_64-1.2" yields 86.0.0.
+func Coerce(s string) (V, bool) {
+	i := strings.IndexFunc(s, func(r rune) bool { return r >= '0' && r <= '9' })
+	if i < 0 {
+		return V{}, false
+	}
+	var ps [3]string
+	rest := s[i:]
+	for n := 0; n < len(ps); n++ {
+		j := 0
+		for j < len(rest) && isDigit(rest[j]) {
+			j++
+		}
+		ps[n], _ = trimLeadingZeroes(rest[:j])
+		rest = rest[j:]
+		if n == len(ps)-1 || len(rest) < 2 || rest[0] != '.' || !isDigit(rest[1]) {
+			break
+		}
+		rest = rest[1:]
+	}
+	v := V{major: ps[0], minor: cmp.Or(ps[1], "0"), patch: cmp.Or(ps[2], "0")}
+	if ps[2] == "" {
+		return v, true
+	}
+	if label, tail, ok := cutLabel(rest, '-'); ok && checkRelease(label) == nil {
+		v.release, rest = label, tail
+	}
+	if label, _, ok := cutLabel(rest, '+'); ok && checkWords(label) == nil {
+		v.build = label
+	}
+	return v, true
+}
+
+// FindAll returns the distinct versions found in text, in ascending order by
+// [CompareFull]. A version is found where text contains a maximal token of
+// letters, digits, and the punctuation ".", "-", and "+" that, after removing
+// trailing periods and an optional leading "v", is a valid version string as
+// accepted by [Parse]. For example, in
+//
+//	"Fixed in v1.2.3 (see 1.3.0-rc.1, 1.2.3); host 127.0.0.1, tag x-1.0.0."
+//
+// FindAll finds 1.2.3 and 1.3.0-rc.1. Note that 127.0.0.1 is not a version,
+// and because tokens are maximal, neither 127.0.0 nor 1.0.0 is reported. Use
+// [Coerce] to extract a single version from less structured text.
+func FindAll(text string) []V {
+	var out []V
+	for i := 0; i < len(text); {
+		if !isTokenByte(text[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(text) && isTokenByte(text[j]) {
+			j++
+		}
+		tok := strings.TrimPrefix(strings.TrimRight(text[i:j], "."), "v")
+		if v, err := Parse(tok); err == nil {
+			out = append(out, v)
+		}
+		i = j
+	}
+	slices.SortFunc(out, CompareFull)
+	return slices.CompactFunc(out, func(a, b V) bool { return CompareFull(a, b) == 0 })
+}
+
+// isTokenByte reports whether b may occur in a version token for [FindAll].
+func isTokenByte(b byte) bool { return b == '.' || b == '+' || isWord(string(b)) }
+
+// cutLabel reports whether s begins with the specified marker followed by a
+// possible label, comprising word characters and dots. If so, it returns the
+// label without trailing dots, and the remainder of s following it.
+func cutLabel(s string, mark byte) (label, rest string, ok bool) {
+	if s == "" || s[0] != mark {
+		return "", s, false
+	}
+	j := 1
+	for j < len(s) && (s[j] == '.' || isWord(s[j:j+1])) {
+		j++
+	}
+	label = strings.TrimRight(s[1:j], ".")
+	return label, s[1+len(label):], label != ""
+}
+
+// ParseFieldLimits returns the [V] represented by s, as [Parse], but also
+// reports an error if the decimal representation of any of the major, minor,
+// or patch versions has more than maxDigits digits. If maxDigits <= 0, the
+// width of the fields is not limited. As for Parse, errors can be converted
+// to a [*ParseError] with [errors.As].
+func ParseFieldLimits(s string, maxDigits int) (V, error) {
+	v, err := Parse(s)
+	if err != nil || maxDigits <= 0 {
+		return v, err
+	}
+	pos := 0
+	for i, f := range [...]string{v.major, v.minor, v.patch} {
+		if len(f) > maxDigits {
+			return V{}, fieldError{field: coreLabels[i], text: f, offset: pos, err: widthError{len(f), maxDigits}}
+		}
+		pos += len(f) + 1
+	}
+	return v, nil
+}
+
 // Clean returns a lexically normalized form of a semver-like string.
 // The following changes are made, if possible:
 //
@@ -352,6 +1239,7 @@ func mustVal(s string) int {
 
 // isNum reports whether s comprises only digits, and if so returns the integer
 // value represented by s. As a special case, if s == "" it returns (0, true).
+// If the value of s exceeds math.MaxInt, it returns (math.MaxInt, true).
 func isNum(s string) (int, bool) {
 	v := 0
 	for i := range s {
@@ -359,11 +1247,93 @@ func isNum(s string) (int, bool) {
 		if d < '0' || d > '9' {
 			return -1, false
 		}
-		v = (v * 10) + int(d-'0')
+		if n := int(d - '0'); v > (math.MaxInt-n)/10 {
+			v = math.MaxInt // saturate
+		} else {
+			v = (v * 10) + n
+		}
 	}
 	return v, true
 }
 
+// compareNum compares the non-negative decimal numbers represented by the
+// digit strings a and b, which may be of any length. An empty string is
+// treated as 0. Leading zeroes are ignored.
+func compareNum(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if c := cmp.Compare(len(a), len(b)); c != 0 {
+		return c
+	}
+	return cmp.Compare(a, b)
+}
+
+// incNum returns the decimal representation of one more than the number
+// represented by the digit string s, which may be of any length. An empty
+// string is treated as 0.
+func incNum(s string) string {
+	buf := []byte(cmp.Or(s, "0"))
+	for i := len(buf) - 1; i >= 0; i-- {
+		if buf[i] < '9' {
+			buf[i]++
+			return string(buf)
+		}
+		buf[i] = '0'
+	}
+	return "1" + string(buf)
+}
+
+// addNum returns the decimal representation of the number represented by the
+// digit string s plus d, or "0" if the sum would be negative. The string may
+// be of any length. An empty string is treated as 0.
+func addNum(s string, d int) string {
+	s = cmp.Or(strings.TrimLeft(s, "0"), "0")
+	if d >= 0 {
+		return sumDigits(s, strconv.Itoa(d))
+	}
+	n := strconv.FormatUint(uint64(-(d+1))+1, 10) // -d, safe for math.MinInt
+	if compareNum(s, n) <= 0 {
+		return "0"
+	}
+	return diffDigits(s, n)
+}
+
+// sumDigits returns the decimal sum of the digit strings a and b.
+func sumDigits(a, b string) string {
+	buf := make([]byte, max(len(a), len(b))+1)
+	var carry byte
+	for i := range buf {
+		d := carry
+		if i < len(a) {
+			d += a[len(a)-1-i] - '0'
+		}
+		if i < len(b) {
+			d += b[len(b)-1-i] - '0'
+		}
+		buf[len(buf)-1-i], carry = d%10+'0', d/10
+	}
+	return cmp.Or(strings.TrimLeft(string(buf), "0"), "0")
+}
+
+// diffDigits returns the decimal difference a - b of the digit strings a and
+// b, where a ≥ b.
+func diffDigits(a, b string) string {
+	buf := []byte(a)
+	var borrow byte
+	for i := range buf {
+		d := borrow
+		if i < len(b) {
+			d += b[len(b)-1-i] - '0'
+		}
+		j := len(buf) - 1 - i
+		if buf[j]-'0' < d {
+			buf[j], borrow = buf[j]+10-d, 1
+		} else {
+			buf[j], borrow = buf[j]-d, 0
+		}
+	}
+	return cmp.Or(strings.TrimLeft(string(buf), "0"), "0")
+}
+
 // isWord reports whether s comprises only digits, letters, and hyphens.
 func isWord(s string) bool {
 	for i := range s {
@@ -376,20 +1346,31 @@ func isWord(s string) bool {
 	return true
 }
 
+// Errors reported by [Parse] and [Conform], which callers may check for using
+// [errors.Is]. The errors reported may include additional context.
+var (
+	ErrBuildMetadata = errors.New("unexpected build metadata") // build metadata where not permitted
+	ErrEmptyBuild    = errors.New("empty build metadata")      // build marker with no metadata
+	ErrEmptyRelease  = errors.New("empty release")             // release marker with no label
+	ErrEmptyWord     = errors.New("empty identifier")          // empty dot-separated identifier
+	ErrInvalidChar   = errors.New("invalid character")         // character not permitted in a field
+	ErrLeadingZero   = errors.New("leading zeroes")            // numeric identifier with leading zeroes
+	ErrNotNumber     = errors.New("not a number")              // non-numeric core version
+)
+
 // Sentinel errors, to avoid allocation during a parse.
 var (
-	errEmptyBuild   = errors.New("empty build metadata")
-	errEmptyRelease = errors.New("empty release")
-	errLeadingZero  = errors.New("leading zeroes")
-	errNotNumber    = errors.New("not a number")
+	errInvalidMarker = errors.New("invalid label marker")
+	errMissingPrefix = errors.New("missing v prefix")
+	errMissingBuild  = errors.New("missing build metadata")
 )
 
 // checkVNum reports an error of s is not a valid version number.
 func checkVNum(s string) error {
 	if _, ok := isNum(s); !ok || s == "" {
-		return errNotNumber
+		return ErrNotNumber
 	} else if s[0] == '0' && s != "0" {
-		return errLeadingZero
+		return ErrLeadingZero
 	}
 	return nil
 }
@@ -427,6 +1408,25 @@ func checkWords(s string) error {
 	return nil
 }
 
+// checkRelease parses s as a dot-separated sequence of words, as checkWords,
+// and also reports an error if any numeric word has leading zeroes, as the
+// spec requires for release labels (but not build metadata).
+//
+// Precondition: s != ""
+func checkRelease(s string) error {
+	if err := checkWords(s); err != nil {
+		return err
+	}
+	for i := 1; s != ""; i++ {
+		w, rest := cutDotWord(s)
+		if _, ok := isNum(w); ok && len(w) > 1 && w[0] == '0' {
+			return leadingZeroPosError(i)
+		}
+		s = rest
+	}
+	return nil
+}
+
 func cutDotWord(s string) (w, rest string) {
 	w, rest, _ = strings.Cut(s, ".")
 	return
@@ -435,13 +1435,16 @@ func cutDotWord(s string) (w, rest string) {
 // compareWords compares a and b lexicographically as a dot-separated sequence
 // of substrings in which each corresponding substring, using compareWord to
 // compare corresponding elements.
+//
+// If one sequence is a prefix of the other, the shorter sequence is first.
 func compareWords(a, b string) int {
 	for {
+		if a == "" || b == "" {
+			return cmp.Compare(len(a), len(b))
+		}
 		wa, ra := cutDotWord(a)
 		wb, rb := cutDotWord(b)
-		if wa == "" && wb == "" {
-			return 0
-		} else if c := compareWord(wa, wb); c != 0 {
+		if c := compareWord(wa, wb); c != 0 {
 			return c
 		}
 		a, b = ra, rb
@@ -449,12 +1452,18 @@ func compareWords(a, b string) int {
 }
 
 // compareWord compares a and b. If both comprise only digits, the comparison
-// is based on their numeric values; otherwise it is lexicographical.
+// is based on their numeric values; if neither does, it is lexicographical.
+// Otherwise, the numeric word is ordered first.
 func compareWord(a, b string) int {
-	va, oka := isNum(a)
-	vb, okb := isNum(b)
-	if oka && okb {
-		return cmp.Compare(va, vb)
+	_, oka := isNum(a)
+	_, okb := isNum(b)
+	switch {
+	case oka && okb:
+		return compareNum(a, b)
+	case oka:
+		return -1
+	case okb:
+		return 1
 	}
 	return cmp.Compare(a, b)
 }
@@ -497,25 +1506,48 @@ func split3(s string) (ss [3]string, err error) {
 // and reports whether a release and/or build label was present.
 // If neither was present, prefix == s.
 func splitReleaseBuild(s string) (prefix, release, build string, hasRelease, hasBuild bool) {
+	return splitMarkers(s, '-', '+')
+}
+
+// splitMarkers splits s into the form "<prefix>[<pre><release>][<bm><build>]"
+// where pre and bm are the specified marker bytes, and reports whether a
+// release and/or build label was present.
+// If neither was present, prefix == s.
+func splitMarkers(s string, pre, bm byte) (prefix, release, build string, hasRelease, hasBuild bool) {
 	prefix = s
-	i := strings.IndexAny(s, "-+")
+	i := strings.IndexByte(s, pre)
+	if j := strings.IndexByte(s, bm); j >= 0 && (i < 0 || j < i) {
+		i = j
+	}
 	if i < 0 {
 		return
 	}
-	rest := s[i:] // N.B. keep the marker
+	rest := s[i+1:]
 	prefix = s[:i]
 
-	if p, ok := strings.CutPrefix(rest, "-"); ok {
-		// rest == "-<release>[+<build>]"
+	if s[i] == pre {
+		// rest == "<release>[<bm><build>]"
 		hasRelease = true
-		release, build, hasBuild = strings.Cut(p, "+")
+		if j := strings.IndexByte(rest, bm); j >= 0 {
+			release, build, hasBuild = rest[:j], rest[j+1:], true
+		} else {
+			release = rest
+		}
 	} else {
-		// rest == "" or rest == "+<build>"
-		build, hasBuild = strings.CutPrefix(rest, "+")
+		// rest == "<build>"
+		build, hasBuild = rest, true
 	}
 	return
 }
 
+// splitWords returns the dot-separated words of s, or nil if s == "".
+func splitWords(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ".")
+}
+
 // joinCleanWords returns a copy of s with all empty words removed.
 func joinCleanWords(s string) string {
 	t := strings.Trim(s, ".")
@@ -544,17 +1576,33 @@ func joinCleanWords(s string) string {
 	return string(buf)
 }
 
+// coreLabels are the names of the core version fields, in order.
+var coreLabels = [...]string{"major", "minor", "patch"}
+
+type widthError struct{ got, max int }
+
+func (e widthError) Error() string {
+	return fmt.Sprintf("too many digits (got %d, max %d)", e.got, e.max)
+}
+
 type countError int
 
 func (c countError) Error() string { return fmt.Sprintf("wrong length (got %d, want 3)", c) }
 
 type emptyWordPosError int
 
-func (e emptyWordPosError) Error() string { return fmt.Sprintf("empty word (pos %d)", int(e)) }
+func (e emptyWordPosError) Error() string   { return fmt.Sprintf("empty word (pos %d)", int(e)) }
+func (emptyWordPosError) Is(err error) bool { return err == ErrEmptyWord }
 
 type invalidCharPosError int
 
-func (e invalidCharPosError) Error() string { return fmt.Sprintf("invalid char (pos %d)", int(e)) }
+func (e invalidCharPosError) Error() string   { return fmt.Sprintf("invalid char (pos %d)", int(e)) }
+func (invalidCharPosError) Is(err error) bool { return err == ErrInvalidChar }
+
+type leadingZeroPosError int
+
+func (e leadingZeroPosError) Error() string   { return fmt.Sprintf("leading zeroes (pos %d)", int(e)) }
+func (leadingZeroPosError) Is(err error) bool { return err == ErrLeadingZero }
 
 type invalidThingError struct {
 	label, thing string
@@ -565,3 +1613,75 @@ func (e invalidThingError) Error() string {
 	return fmt.Sprintf("invalid %s %q: %v", e.label, e.thing, e.err)
 }
 func (e invalidThingError) Unwrap() error { return e.err }
+
+// fieldError is the concrete type of errors reported by Parse. It formats as
+// an invalidThingError, or if bare is set as err alone, but also supports
+// conversion to a *ParseError via errors.As.
+type fieldError struct {
+	field, text string
+	offset      int // byte offset of the error in the input
+	err         error
+	bare        bool // if true, format only err
+}
+
+// newFieldError constructs a fieldError for a problem with the specified
+// text of field, starting at offset pos of the input. If err reports the
+// position of a word within text, the offset is adjusted to match.
+func newFieldError(field, text string, pos int, err error) fieldError {
+	switch e := err.(type) {
+	case emptyWordPosError:
+		pos += wordOffset(text, int(e))
+	case leadingZeroPosError:
+		pos += wordOffset(text, int(e))
+	case invalidCharPosError:
+		// Report the first invalid character of the word.
+		i := wordOffset(text, int(e))
+		for i < len(text) && isWord(text[i:i+1]) {
+			i++
+		}
+		pos += i
+	}
+	return fieldError{field: field, text: text, offset: pos, err: err}
+}
+
+func (e fieldError) Error() string {
+	if e.bare {
+		return e.err.Error()
+	}
+	return invalidThingError{e.field, e.text, e.err}.Error()
+}
+
+func (e fieldError) Unwrap() error { return e.err }
+
+func (e fieldError) As(target any) bool {
+	if p, ok := target.(**ParseError); ok {
+		*p = &ParseError{Field: e.field, Offset: e.offset, Err: e.err}
+		return true
+	}
+	return false
+}
+
+// lengthError returns an error for a core version with n fields, where
+// n != 3, and ps is the result of split3(core).
+func lengthError(core string, ps [3]string, n countError) error {
+	if n < 3 {
+		return fieldError{field: coreLabels[n], offset: len(core), err: n, bare: true}
+	}
+	// Report the position of the first excess separator.
+	pos := len(ps[0]) + len(ps[1]) + 2 + strings.IndexByte(ps[2], '.')
+	return fieldError{field: "patch", offset: pos, err: n, bare: true}
+}
+
+// wordOffset returns the byte offset in s of the start of its nth
+// dot-separated word, counting from 1.
+func wordOffset(s string, n int) int {
+	var pos int
+	for ; n > 1; n-- {
+		i := strings.IndexByte(s[pos:], '.')
+		if i < 0 {
+			break
+		}
+		pos += i + 1
+	}
+	return pos
+}
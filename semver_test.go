@@ -3,7 +3,11 @@
 package semver_test
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
 	"strings"
 	"testing"
 
@@ -73,6 +77,15 @@ func TestOrder(t *testing.T) {
 		{"1.0.0-rc.1", "1.0.0", -1},
 		{"1.57.0", "1.57.0-beta1", 1},
 
+		// A longer release follows its prefix, even if the extra words are 0.
+		{"1.0.0-rc", "1.0.0-rc.0", -1},
+		{"1.0.0-0", "1.0.0-0.0", -1},
+
+		// Numeric identifiers precede alphanumeric ones, regardless of content.
+		{"1.0.0-2", "1.0.0-10a", -1},
+		{"1.0.0-99", "1.0.0--", -1},
+		{"1.0.0-rc.5", "1.0.0-rc.-5", -1},
+
 		// Build metadata do not affect comparison.
 		{"1.2.3-four+five.six", "1.2.3-four", 0},
 		{"1.2.3-four", "1.2.3-four+five", 0},
@@ -132,6 +145,223 @@ func TestOrder(t *testing.T) {
 	})
 }
 
+func TestLargeNumbers(t *testing.T) {
+	// Numeric identifiers have no upper bound, and must compare correctly even
+	// when they exceed the range of an int.
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"9223372036854775808.0.0", "9223372036854775807.0.0", 1},     // 2^63 vs. 2^63-1
+		{"18446744073709551616.0.0", "18446744073709551615.0.0", 1},   // 2^64 vs. 2^64-1
+		{"99999999999999999999.0.0", "100000000000000000000.0.0", -1}, // length differs
+		{"1.99999999999999999999.0", "1.99999999999999999998.5", 1},   // minor
+		{"1.2.36893488147419103232", "1.2.36893488147419103231", 1},   // patch, 2^65
+		{"1.0.0-rc.18446744073709551616", "1.0.0-rc.18446744073709551615", 1},
+		{"1.0.0-18446744073709551616", "1.0.0-9", 1},
+		{"1.0.0-99999999999999999999", "1.0.0-a", -1},
+		{"99999999999999999999.0.0", "99999999999999999999.0.0+b", 0},
+	}
+	for _, tc := range tests {
+		a, b := mustParse(t, tc.a), mustParse(t, tc.b)
+		if got := semver.Compare(a, b); got != tc.want {
+			t.Errorf("Compare(%v, %v): got %d, want %d", a, b, got, tc.want)
+		}
+		if got := semver.Compare(b, a); got != -tc.want {
+			t.Errorf("Compare(%v, %v): got %d, want %d", b, a, got, -tc.want)
+		}
+	}
+
+	// Values that do not fit in an int are reported as math.MaxInt.
+	v := mustParse(t, "18446744073709551616.99999999999999999999.9223372036854775808")
+	if v.Major() != math.MaxInt || v.Minor() != math.MaxInt || v.Patch() != math.MaxInt {
+		t.Errorf("[%v] core: got %d.%d.%d, want MaxInt", v, v.Major(), v.Minor(), v.Patch())
+	}
+	if v := mustParse(t, "9223372036854775807.0.0"); v.Major() != math.MaxInt {
+		t.Errorf("[%v].Major(): got %d, want %d", v, v.Major(), math.MaxInt)
+	}
+
+	w := mustParse(t, "1.0.0-rc.99999999999999999999")
+	if got, want := w.NextInWorkflow("rc").String(), "1.0.0-rc.100000000000000000000"; got != want {
+		t.Errorf("[%v].NextInWorkflow: got %q, want %q", w, got, want)
+	}
+
+	// Add works on the digits, not on the saturated int values.
+	adds := []struct {
+		input                  string
+		dmajor, dminor, dpatch int
+		want                   string
+	}{
+		{"99999999999999999999.2.3", 1, 0, 0, "100000000000000000000.2.3"},
+		{"99999999999999999999.2.3", 0, 0, 1, "99999999999999999999.2.4"},
+		{"9223372036854775807.0.0", 1, 0, 0, "9223372036854775808.0.0"},
+		{"100000000000000000000.0.0", -1, 0, 0, "99999999999999999999.0.0"},
+		{"1.18446744073709551616.0", 0, -math.MaxInt, 0, "1.9223372036854775809.0"},
+		{"1.18446744073709551616.0", 0, math.MinInt, 0, "1.9223372036854775808.0"},
+		{"1.2.9223372036854775808", 0, 0, math.MinInt, "1.2.0"},
+		{"1.2.9223372036854775807", 0, 0, math.MinInt, "1.2.0"},
+	}
+	for _, tc := range adds {
+		v := mustParse(t, tc.input)
+		if got := v.Add(tc.dmajor, tc.dminor, tc.dpatch); got.String() != tc.want {
+			t.Errorf("[%v].Add(%d, %d, %d): got %v, want %s", v, tc.dmajor, tc.dminor, tc.dpatch, got, tc.want)
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		v, lo, hi          string
+		between, inclusive bool
+	}{
+		{"1.2.3", "1.2.3", "1.3.0", true, true},
+		{"1.2.9", "1.2.3", "1.3.0", true, true},
+		{"1.3.0", "1.2.3", "1.3.0", false, true},
+		{"1.3.0+b", "1.2.3", "1.3.0", false, true},
+		{"1.3.0-rc1", "1.2.3", "1.3.0", true, true},
+		{"1.2.3-rc1", "1.2.3", "1.3.0", false, false},
+		{"1.2.2", "1.2.3", "1.3.0", false, false},
+		{"1.4.0", "1.2.3", "1.3.0", false, false},
+
+		// Degenerate intervals.
+		{"1.2.3", "1.2.3", "1.2.3", false, true},
+		{"1.2.3", "1.3.0", "1.2.0", false, false},
+		{"1.2.5", "1.3.0", "1.2.0", false, false},
+	}
+	for _, tc := range tests {
+		v, lo, hi := mustParse(t, tc.v), mustParse(t, tc.lo), mustParse(t, tc.hi)
+		if got := v.Between(lo, hi); got != tc.between {
+			t.Errorf("[%v].Between(%v, %v): got %v, want %v", v, lo, hi, got, tc.between)
+		}
+		if got := v.BetweenInclusive(lo, hi); got != tc.inclusive {
+			t.Errorf("[%v].BetweenInclusive(%v, %v): got %v, want %v", v, lo, hi, got, tc.inclusive)
+		}
+	}
+}
+
+func TestClamp(t *testing.T) {
+	tests := []struct {
+		v, lo, hi, want string
+	}{
+		{"1.2.3", "1.0.0", "2.0.0", "1.2.3"},
+		{"0.9.0", "1.0.0", "2.0.0", "1.0.0"},
+		{"2.0.1", "1.0.0", "2.0.0", "2.0.0"},
+		{"2.0.0-rc1", "1.0.0", "2.0.0", "2.0.0-rc1"},
+		{"1.0.0-rc1", "1.0.0", "2.0.0", "1.0.0"},
+		{"1.0.0+b", "1.0.0", "2.0.0", "1.0.0+b"},
+		{"2.0.0+b", "1.0.0", "2.0.0", "2.0.0+b"},
+		{"1.5.0", "1.5.0", "1.5.0", "1.5.0"},
+
+		// Degenerate intervals yield lo.
+		{"1.5.0", "2.0.0", "1.0.0", "2.0.0"},
+		{"0.5.0", "2.0.0", "1.0.0", "2.0.0"},
+		{"3.0.0", "2.0.0", "1.0.0", "2.0.0"},
+	}
+	for _, tc := range tests {
+		v, lo, hi := mustParse(t, tc.v), mustParse(t, tc.lo), mustParse(t, tc.hi)
+		if got := v.Clamp(lo, hi); got.String() != tc.want {
+			t.Errorf("[%v].Clamp(%v, %v): got %v, want %s", v, lo, hi, got, tc.want)
+		}
+	}
+}
+
+func TestCompareCore(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"1.2.3-rc1", "1.2.3", 0},
+		{"1.2.3-alpha", "1.2.3-beta", 0},
+		{"1.2.3+x", "1.2.3-rc1+y", 0},
+		{"1.2.3", "1.2.4-rc1", -1},
+		{"1.2.9", "1.3.0", -1},
+		{"1.10.0", "1.9.5", 1},
+		{"2.0.0-rc1", "1.99.99", 1},
+		{"0.0.10", "0.0.9", 1},
+	}
+	for _, tc := range tests {
+		a, b := mustParse(t, tc.a), mustParse(t, tc.b)
+		if got := semver.CompareCore(a, b); got != tc.want {
+			t.Errorf("CompareCore(%v, %v): got %d, want %d", a, b, got, tc.want)
+		}
+		if got := semver.CompareCore(b, a); got != -tc.want {
+			t.Errorf("CompareCore(%v, %v): got %d, want %d", b, a, got, -tc.want)
+		}
+		if got, want := semver.CompareCore(a, b), semver.Compare(a.Core(), b.Core()); got != want {
+			t.Errorf("CompareCore(%v, %v): got %d, but Compare of cores is %d", a, b, got, want)
+		}
+	}
+
+	// Unlike Compare, a prerelease is not ordered before its stable release.
+	pre, rel := mustParse(t, "1.2.3-rc1"), mustParse(t, "1.2.3")
+	if got := semver.Compare(pre, rel); got != -1 {
+		t.Errorf("Compare(%v, %v): got %d, want -1", pre, rel, got)
+	}
+}
+
+func TestCompareMinor(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.9", 0},
+		{"1.2.3", "1.2.0-rc1", 0},
+		{"1.2.3+x", "1.2.3-alpha+y", 0},
+		{"1.2.9", "1.3.0", -1},
+		{"1.10.0", "1.9.5", 1},
+		{"2.0.0-rc1", "1.99.99", 1},
+		{"0.1.0", "1.0.0", -1},
+	}
+	for _, tc := range tests {
+		a, b := mustParse(t, tc.a), mustParse(t, tc.b)
+		if got := semver.CompareMinor(a, b); got != tc.want {
+			t.Errorf("CompareMinor(%v, %v): got %d, want %d", a, b, got, tc.want)
+		}
+		if got := semver.CompareMinor(b, a); got != -tc.want {
+			t.Errorf("CompareMinor(%v, %v): got %d, want %d", b, a, got, -tc.want)
+		}
+	}
+}
+
+func TestCompareFull(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"1.2.3+a", "1.2.3+a", 0},
+		{"1.2.3", "1.2.4", -1},
+		{"1.2.3+z", "1.2.4+a", -1},
+		{"1.2.3-rc1+z", "1.2.3+a", -1},
+		{"1.2.3", "1.2.3+a", -1},
+		{"1.2.3+2", "1.2.3+10", -1},
+		{"1.2.3+10", "1.2.3+a", -1},
+		{"1.2.3+a", "1.2.3+a.0", -1},
+		{"1.2.3+a.b", "1.2.3+a.c", -1},
+		{"1.2.3+007", "1.2.3+8", -1},
+		{"1.2.3+07", "1.2.3+7", -1},
+	}
+	for _, tc := range tests {
+		a, b := mustParse(t, tc.a), mustParse(t, tc.b)
+		if got := semver.CompareFull(a, b); got != tc.want {
+			t.Errorf("CompareFull(%v, %v): got %d, want %d", a, b, got, tc.want)
+		}
+		if got := semver.CompareFull(b, a); got != -tc.want {
+			t.Errorf("CompareFull(%v, %v): got %d, want %d", b, a, got, -tc.want)
+		}
+	}
+
+	// Sorting by CompareFull does not depend on input order.
+	vs := []semver.V{
+		mustParse(t, "1.0.0+b"), mustParse(t, "1.0.0"), mustParse(t, "1.0.0+a"), mustParse(t, "0.9.0+x"),
+	}
+	slices.SortFunc(vs, semver.CompareFull)
+	if got, want := fmt.Sprint(vs), "[0.9.0+x 1.0.0 1.0.0+a 1.0.0+b]"; got != want {
+		t.Errorf("SortFunc(CompareFull): got %s, want %s", got, want)
+	}
+}
+
 func TestFormat(t *testing.T) {
 	tests := []struct {
 		input semver.V
@@ -144,6 +374,10 @@ func TestFormat(t *testing.T) {
 		{semver.New(5, 1, 0).WithRelease("rc1.c030"), "5.1.0-rc1.c030"},
 		{semver.New(0, 0, 9).WithBuild("custom").WithRelease("alpha5.2"), "0.0.9-alpha5.2+custom"},
 		{semver.MustParse("1.2.3-four.five+six").Core(), "1.2.3"},
+		{semver.MustParse("1.2.3-four.five+six").MajorMinor(), "1.2.0"},
+		{semver.MustParse("10.20.0").MajorMinor(), "10.20.0"},
+		{semver.MustParse("1.99999999999999999999.5").MajorMinor(), "1.99999999999999999999.0"},
+		{semver.V{}.MajorMinor(), "0.0.0"},
 		{semver.MustParse("1.2.3+four").WithBuild(""), "1.2.3"},
 		{semver.V{}.WithRelease("rc1.2.3-4"), "0.0.0-rc1.2.3-4"},
 		{semver.New(1, 2, 3).WithBuild("a..b."), "1.2.3+a.b"},
@@ -157,6 +391,120 @@ func TestFormat(t *testing.T) {
 	}
 }
 
+func TestIsZero(t *testing.T) {
+	tests := []struct {
+		input semver.V
+		want  bool
+	}{
+		{semver.V{}, true},
+		{semver.V{}.WithRelease(""), true},
+		{semver.New(0, 0, 0), false},
+		{semver.MustParse("0.0.0"), false},
+		{semver.V{}.WithBuild("x"), false},
+		{semver.V{}.WithRelease("rc"), false},
+		{semver.V{}.WithMinor(0), false},
+	}
+	for _, tc := range tests {
+		if got := tc.input.IsZero(); got != tc.want {
+			t.Errorf("%#v.IsZero(): got %v, want %v", tc.input, got, tc.want)
+		}
+	}
+	if got := (semver.V{}).String(); got != "0.0.0" {
+		t.Errorf("Zero String: got %q, want 0.0.0", got)
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		input semver.V
+		want  string
+	}{
+		{semver.V{}, "0.0.0"},
+		{semver.MustParse("1.2.3-rc.1+build.05"), "1.2.3-rc.1+build.05"},
+		{semver.New(1, 2, 3).WithRelease("rc.01"), "1.2.3-rc.1"},
+		{semver.New(1, 2, 3).WithRelease("00.0a.000"), "1.2.3-0.0a.0"},
+		{semver.New(1, 2, 3).WithRelease("x..y").WithBuild("007"), "1.2.3-x.y+007"},
+	}
+	for _, tc := range tests {
+		got := tc.input.Canonical()
+		if got != tc.want {
+			t.Errorf("[%v].Canonical(): got %q, want %q", tc.input, got, tc.want)
+		}
+		if !semver.IsCanonical(got) {
+			t.Errorf("IsCanonical(%q): got false, want true", got)
+		}
+	}
+
+	for _, s := range []string{
+		"", "v1.2.3", "1.2", "1.02.3", "1.2.3-a..b", " 1.2.3", "1.2.3-", "1.2.3-rc.01", "junk",
+	} {
+		if semver.IsCanonical(s) {
+			t.Errorf("IsCanonical(%q): got true, want false", s)
+		}
+	}
+}
+
+func TestStringV(t *testing.T) {
+	for _, s := range []string{"0.0.0", "1.2.3", "1.2.3-rc.1+b"} {
+		v := mustParse(t, s)
+		got := v.StringV()
+		if got != "v"+s {
+			t.Errorf("[%v].StringV(): got %q, want %q", v, got, "v"+s)
+		}
+		if w, err := semver.ParseClean(got); err != nil || w != v {
+			t.Errorf("ParseClean(%q): got (%v, %v), want %v", got, w, err, v)
+		}
+	}
+	if got := (semver.V{}).StringV(); got != "v0.0.0" {
+		t.Errorf("Zero StringV: got %q, want v0.0.0", got)
+	}
+}
+
+func TestMergeBuild(t *testing.T) {
+	tests := []struct {
+		input string
+		parts []string
+		want  string
+	}{
+		{"1.2.3", nil, "1.2.3"},
+		{"1.2.3+old", nil, "1.2.3"},
+		{"1.2.3+old", []string{"new"}, "1.2.3+new"},
+		{"1.2.3-rc1", []string{"linux.amd64", "git.abc123", "amd64.2024"}, "1.2.3-rc1+linux.amd64.git.abc123.2024"},
+		{"1.2.3", []string{"a..b.", "", ".c.a", "b.d"}, "1.2.3+a.b.c.d"},
+		{"1.2.3", []string{"x.x.x"}, "1.2.3+x"},
+		{"1.2.3", []string{"..", "."}, "1.2.3"},
+	}
+	for _, tc := range tests {
+		v := mustParse(t, tc.input)
+		if got := v.MergeBuild(tc.parts...); got.String() != tc.want {
+			t.Errorf("[%v].MergeBuild(%q): got %q, want %q", v, tc.parts, got, tc.want)
+		}
+	}
+}
+
+func TestRoundTrips(t *testing.T) {
+	tests := []struct {
+		input semver.V
+		want  bool
+	}{
+		{semver.V{}, true},
+		{semver.New(1, 2, 3), true},
+		{semver.MustParse("1.2.3-rc.1+build.5"), true},
+		{semver.MustParse("0.0.0-0A.is.legal+0.build.1-rc.10000aaa-kk-0.1"), true},
+		{semver.New(1, 2, 3).WithBuild("a..b"), true},
+		{semver.New(1, 2, 3).WithRelease(".rc..1.").WithBuild("x."), true},
+		{semver.New(1, 2, 3).Add(0, 1, -5), true},
+		{semver.New(1, 2, 3).WithRelease("bad label"), false},
+		{semver.New(1, 2, 3).WithBuild("a+b"), false},
+		{semver.New(1, 2, 3).WithRelease("rc+b"), false},
+	}
+	for _, tc := range tests {
+		if got := tc.input.RoundTrips(); got != tc.want {
+			t.Errorf("[%v].RoundTrips(): got %v, want %v", tc.input, got, tc.want)
+		}
+	}
+}
+
 func TestParseClean(t *testing.T) {
 	tests := []struct {
 		input string
@@ -192,6 +540,53 @@ func TestParseClean(t *testing.T) {
 	}
 }
 
+func TestParseMarkers(t *testing.T) {
+	tests := []struct {
+		input         string
+		pre, build    byte
+		want, errText string
+	}{
+		{"1.2.3", '~', '_', "1.2.3", ""},
+		{"1.2.3~rc1", '~', '_', "1.2.3-rc1", ""},
+		{"1.2.3_b5", '~', '_', "1.2.3+b5", ""},
+		{"1.2.3~rc1.2_b5.x", '~', '_', "1.2.3-rc1.2+b5.x", ""},
+		{"1.2.3~rc-1_b-5", '~', '_', "1.2.3-rc-1+b-5", ""},
+		{"1.2.3-rc1+b", '-', '+', "1.2.3-rc1+b", ""},
+		{"1.2.3_b~c", '~', '_', "", "invalid build"},
+		{"1.2.3~", '~', '_', "", "empty release"},
+		{"1.2.3-rc1", '~', '_', "", "invalid patch"},
+		{"1.2.3~rc1+b", '~', '_', "", "invalid release"},
+
+		// Invalid markers.
+		{"1.2.3", '~', '~', "", "invalid label marker"},
+		{"1.2.3", '.', '_', "", "invalid label marker"},
+		{"1.2.3", '~', 'x', "", "invalid label marker"},
+		{"1.2.3", '0', '+', "", "invalid label marker"},
+		{"1.2.3", '-', 'Z', "", "invalid label marker"},
+	}
+	for _, tc := range tests {
+		v, err := semver.ParseMarkers(tc.input, tc.pre, tc.build)
+		if tc.errText != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.errText) {
+				t.Errorf("ParseMarkers(%q, %q, %q): got (%v, %v), want error %q",
+					tc.input, tc.pre, tc.build, v, err, tc.errText)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("ParseMarkers(%q, %q, %q): unexpected error: %v", tc.input, tc.pre, tc.build, err)
+			continue
+		}
+		if got := v.String(); got != tc.want {
+			t.Errorf("ParseMarkers(%q, %q, %q): got %q, want %q", tc.input, tc.pre, tc.build, got, tc.want)
+		}
+
+		// The standard format should round-trip through Parse.
+		if w, err := semver.Parse(v.String()); err != nil || w != v {
+			t.Errorf("Parse %q: got (%v, %v), want %v", v.String(), w, err, v)
+		}
+	}
+}
+
 func TestErrors(t *testing.T) {
 	tests := []struct {
 		input string
@@ -217,6 +612,10 @@ func TestErrors(t *testing.T) {
 		{"4.5.6-ok.123+.a", `build ".a": empty word (pos 1)`},
 		{"1.0.0-bo?gus", `release "bo?gus": invalid char (pos 1)`},
 		{"1.4.0+is.b@d", `build "is.b@d": invalid char (pos 2)`},
+		{"1.0.0-1.01", `release "1.01": leading zeroes (pos 2)`},
+		{"1.0.0-alpha.01", `release "alpha.01": leading zeroes (pos 2)`},
+		{"1.0.0-00", `release "00": leading zeroes (pos 1)`},
+		{"1.0.0-rc.1.007+b", `release "rc.1.007": leading zeroes (pos 3)`},
 	}
 	for _, tc := range tests {
 		got, err := semver.Parse(tc.input)
@@ -230,6 +629,280 @@ func TestErrors(t *testing.T) {
 			t.Errorf("IsValid %q: got true, want false", tc.input)
 		}
 	}
+
+	// Leading zeroes are permitted in alphanumeric release identifiers, and in
+	// build metadata.
+	for _, s := range []string{"1.0.0-1.0", "1.0.0-x.0", "1.0.0-0", "1.0.0-0a.01a", "1.0.0+01", "1.0.0-1+0.007"} {
+		if _, err := semver.Parse(s); err != nil {
+			t.Errorf("Parse %q: unexpected error: %v", s, err)
+		}
+	}
+}
+
+func TestParseErrorDetail(t *testing.T) {
+	tests := []struct {
+		input  string
+		field  string
+		offset int
+		want   error
+	}{
+		{"", "major", 0, nil},
+		{"1.2", "patch", 3, nil},
+		{"1.2.3.4", "patch", 5, nil},
+		{"q.0.3", "major", 0, semver.ErrNotNumber},
+		{"1..3", "minor", 2, semver.ErrNotNumber},
+		{"1.06.1", "minor", 2, semver.ErrLeadingZero},
+		{"1.2.07", "patch", 4, semver.ErrLeadingZero},
+		{"2.4.0-", "release", 6, semver.ErrEmptyRelease},
+		{"2.4.0-ok+", "build", 9, semver.ErrEmptyBuild},
+		{"1.0.0+", "build", 6, semver.ErrEmptyBuild},
+		{"0.1.2-a..b", "release", 8, semver.ErrEmptyWord},
+		{"1.2.3+a.b.", "build", 10, semver.ErrEmptyWord},
+		{"1.0.0-bo?gus", "release", 8, semver.ErrInvalidChar},
+		{"1.4.0+is.b@d", "build", 10, semver.ErrInvalidChar},
+		{"1.0.0-rc.1.007+b", "release", 11, semver.ErrLeadingZero},
+		{"10.20.30-x.y+a.b.c!", "build", 18, semver.ErrInvalidChar},
+	}
+	for _, tc := range tests {
+		_, err := semver.Parse(tc.input)
+		var perr *semver.ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("Parse %q: got %v, want *ParseError", tc.input, err)
+			continue
+		}
+		if perr.Field != tc.field || perr.Offset != tc.offset {
+			t.Errorf("Parse %q: got field %q offset %d, want %q, %d",
+				tc.input, perr.Field, perr.Offset, tc.field, tc.offset)
+		}
+		if tc.want != nil && !errors.Is(err, tc.want) {
+			t.Errorf("Parse %q: got %v, want %v", tc.input, err, tc.want)
+		}
+	}
+
+	// Parse errors wrapped by other functions remain inspectable.
+	if _, err := semver.ParseConstraint(">=1.02.0"); !errors.Is(err, semver.ErrLeadingZero) {
+		t.Errorf("ParseConstraint: got %v, want %v", err, semver.ErrLeadingZero)
+	}
+	if _, err := semver.ParseClean("1.2.3-a.b..c$"); !errors.Is(err, semver.ErrInvalidChar) {
+		t.Errorf("ParseClean: got %v, want %v", err, semver.ErrInvalidChar)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	type diag struct {
+		field  string
+		offset int
+	}
+	tests := []struct {
+		input string
+		want  []diag
+	}{
+		{"1.2.3", nil},
+		{"1.2.3-rc.1+b.007", nil},
+		{"", []diag{{"major", 0}}},
+		{"1.02", []diag{{"minor", 2}, {"patch", 4}}},
+		{"01.02.03", []diag{{"major", 0}, {"minor", 3}, {"patch", 6}}},
+		{"1.2.3.4", []diag{{"patch", 5}}},
+		{"1.02.x-", []diag{{"minor", 2}, {"patch", 5}, {"release", 7}}},
+		{"1.02.3+b@d", []diag{{"minor", 2}, {"build", 8}}},
+		{"1.2.3-01.a..b+x.$", []diag{{"release", 6}, {"release", 11}, {"build", 16}}},
+		{"1.2.3-+", []diag{{"release", 6}, {"build", 7}}},
+	}
+	for _, tc := range tests {
+		errs := semver.Validate(tc.input)
+		var got []diag
+		for _, err := range errs {
+			var perr *semver.ParseError
+			if !errors.As(err, &perr) {
+				t.Errorf("Validate %q: got %v, want *ParseError", tc.input, err)
+				continue
+			}
+			got = append(got, diag{perr.Field, perr.Offset})
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("Validate %q: got %v, want %v", tc.input, got, tc.want)
+		}
+
+		// Validate should agree with Parse about validity, and report the error
+		// that Parse does among others.
+		_, perr := semver.Parse(tc.input)
+		if (perr == nil) != (len(errs) == 0) {
+			t.Errorf("Validate %q: got %v, but Parse reports %v", tc.input, errs, perr)
+		} else if perr != nil && !slices.ContainsFunc(errs, func(err error) bool {
+			return err.Error() == perr.Error()
+		}) {
+			t.Errorf("Validate %q: got %v, want to include %v", tc.input, errs, perr)
+		}
+	}
+}
+
+func TestParseWith(t *testing.T) {
+	var (
+		vPrefix = semver.AllowVPrefix()
+		partial = semver.AllowPartial()
+		noBuild = semver.DisallowBuild()
+	)
+	tests := []struct {
+		input   string
+		opts    []semver.ParseOption
+		want    string
+		errText string
+	}{
+		// With no options, the grammar is as for Parse.
+		{"1.2.3-rc.1+b", nil, "1.2.3-rc.1+b", ""},
+		{"v1.2.3", nil, "", "invalid major"},
+		{"1.2", nil, "", "wrong length"},
+
+		{"v1.2.3", []semver.ParseOption{vPrefix}, "1.2.3", ""},
+		{"1.2.3", []semver.ParseOption{vPrefix}, "1.2.3", ""},
+		{"vv1.2.3", []semver.ParseOption{vPrefix}, "", "invalid major"},
+		{"v1.2", []semver.ParseOption{vPrefix}, "", "wrong length"},
+
+		{"1", []semver.ParseOption{partial}, "1.0.0", ""},
+		{"1.2", []semver.ParseOption{partial}, "1.2.0", ""},
+		{"1.2-rc.1+b", []semver.ParseOption{partial}, "1.2.0-rc.1+b", ""},
+		{"1+b", []semver.ParseOption{partial}, "1.0.0+b", ""},
+		{"1.", []semver.ParseOption{partial}, "", "invalid minor"},
+		{"", []semver.ParseOption{partial}, "", "wrong length"},
+		{"v1.2", []semver.ParseOption{partial}, "", "invalid major"},
+		{" 1.2", []semver.ParseOption{partial}, "", "invalid major"},
+
+		{"1.2.3-rc.1", []semver.ParseOption{noBuild}, "1.2.3-rc.1", ""},
+		{"1.2.3+b", []semver.ParseOption{noBuild}, "", "unexpected build metadata"},
+		{"1.2.3-rc.1+b.2", []semver.ParseOption{noBuild}, "", "unexpected build metadata"},
+
+		// Options compose.
+		{"v1.2", []semver.ParseOption{vPrefix, partial}, "1.2.0", ""},
+		{"v1-rc.1", []semver.ParseOption{partial, vPrefix, noBuild}, "1.0.0-rc.1", ""},
+		{"v1+b", []semver.ParseOption{partial, vPrefix, noBuild}, "", "unexpected build metadata"},
+		{"v1.2.03", []semver.ParseOption{vPrefix, partial}, "", "leading zeroes"},
+	}
+	for _, tc := range tests {
+		v, err := semver.ParseWith(tc.input, tc.opts...)
+		if tc.errText != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.errText) {
+				t.Errorf("ParseWith(%q, %d opts): got (%v, %v), want error %q", tc.input, len(tc.opts), v, err, tc.errText)
+			}
+		} else if err != nil {
+			t.Errorf("ParseWith(%q, %d opts): unexpected error: %v", tc.input, len(tc.opts), err)
+		} else if got := v.String(); got != tc.want {
+			t.Errorf("ParseWith(%q, %d opts): got %q, want %q", tc.input, len(tc.opts), got, tc.want)
+		}
+	}
+
+	// Error offsets refer to the original input.
+	offsets := []struct {
+		input  string
+		opts   []semver.ParseOption
+		field  string
+		offset int
+	}{
+		{"v1.02.3", []semver.ParseOption{vPrefix}, "minor", 3},
+		{"v1.2.3+x", []semver.ParseOption{vPrefix, noBuild}, "build", 7},
+		{"1.2.3-a+x.y", []semver.ParseOption{noBuild}, "build", 8},
+		{"01.2", []semver.ParseOption{partial}, "major", 0},
+		{"1.2+x", []semver.ParseOption{partial, noBuild}, "build", 4},
+		{"1-rc.01", []semver.ParseOption{partial}, "release", 5},
+		{"v1-a..b", []semver.ParseOption{partial, vPrefix}, "release", 5},
+	}
+	for _, tc := range offsets {
+		_, err := semver.ParseWith(tc.input, tc.opts...)
+		var perr *semver.ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("ParseWith(%q): got %v, want *ParseError", tc.input, err)
+		} else if perr.Field != tc.field || perr.Offset != tc.offset {
+			t.Errorf("ParseWith(%q): got %s at %d, want %s at %d", tc.input, perr.Field, perr.Offset, tc.field, tc.offset)
+		}
+	}
+
+	if _, err := semver.ParseWith("1.2.3+x", noBuild); !errors.Is(err, semver.ErrBuildMetadata) {
+		t.Errorf("ParseWith(1.2.3+x, DisallowBuild): got %v, want %v", err, semver.ErrBuildMetadata)
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	for _, s := range []string{
+		"0.0.0", "1.2.3", "1.2.3-rc.1+b.5", "", "1.2", "1.02.3", "1.2.3-", "1.2.3-a..b", "1.2.3+x@y",
+	} {
+		want, wantErr := semver.Parse(s)
+		buf := []byte(s)
+		got, gotErr := semver.ParseBytes(buf)
+		if got != want || fmt.Sprint(gotErr) != fmt.Sprint(wantErr) {
+			t.Errorf("ParseBytes(%q): got (%v, %v), want (%v, %v)", s, got, gotErr, want, wantErr)
+		}
+
+		// The result must not share memory with the input.
+		for i := range buf {
+			buf[i] = 'X'
+		}
+		if got != want {
+			t.Errorf("ParseBytes(%q): result changed after input was modified: %v", s, got)
+		}
+	}
+}
+
+func TestCoerce(t *testing.T) {
+	tests := []struct {
+		input, want string // want == "" means not found
+	}{
+		{"", ""},
+		{"no digits here", ""},
+		{"1.2.3", "1.2.3"},
+		{"release-1.2 (final)", "1.2.0"},
+		{"v1.2.3_amd64", "1.2.3"},
+		{"version 7", "7.0.0"},
+		{"foo-1.2.3-rc.1+b5.x", "1.2.3-rc.1+b5.x"},
+		{"1.2.3+b5 (built)", "1.2.3+b5"},
+		{"1.2.3-rc.1.", "1.2.3-rc.1"},
+		{"1.2.3-rc.01", "1.2.3"},
+		{"1.2.3-rc..1+ok", "1.2.3"},
+		{"1.2.3-+b", "1.2.3"},
+		{"1.2.3-rc1+b..c", "1.2.3-rc1"},
+		{"1.2-rc1", "1.2.0"},
+		{"1.2.3.4", "1.2.3"},
+		{"01.002.0003", "1.2.3"},
+		{"1..2", "1.0.0"},
+		{"1.x.2", "1.0.0"},
+		{"x86_64-1.2", "86.0.0"},
+		{"99999999999999999999.1", "99999999999999999999.1.0"},
+	}
+	for _, tc := range tests {
+		v, ok := semver.Coerce(tc.input)
+		if tc.want == "" {
+			if ok {
+				t.Errorf("Coerce(%q): got %v, want not found", tc.input, v)
+			}
+			continue
+		}
+		if !ok || v.String() != tc.want {
+			t.Errorf("Coerce(%q): got (%v, %v), want %q", tc.input, v, ok, tc.want)
+		}
+		if !v.RoundTrips() {
+			t.Errorf("Coerce(%q): result %v is not a valid version", tc.input, v)
+		}
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"", nil},
+		{"nothing to see here", nil},
+		{"1.2.3", []string{"1.2.3"}},
+		{"Fixed in v1.2.3 (see 1.3.0-rc.1, 1.2.3); host 127.0.0.1, tag x-1.0.0.",
+			[]string{"1.2.3", "1.3.0-rc.1"}},
+		{"1.2.3,1.2.4;1.2.3+b", []string{"1.2.3", "1.2.3+b", "1.2.4"}},
+		{"released 2.0.0. Then 10.0.0!", []string{"2.0.0", "10.0.0"}},
+		{"1.2 and 1.2.3.4 and 01.2.3 and 1.2.3-", nil},
+		{"vv1.2.3 V1.2.3 v1.2.3", []string{"1.2.3"}},
+		{"[1.0.0-alpha+001]\t<1.0.0-beta>\n\"1.0.0\"", []string{"1.0.0-alpha+001", "1.0.0-beta", "1.0.0"}},
+	}
+	for _, tc := range tests {
+		got := semver.FindAll(tc.input)
+		checkVersions(t, fmt.Sprintf("FindAll(%q)", tc.input), got, tc.want...)
+	}
 }
 
 func TestClean(t *testing.T) {
@@ -295,32 +968,97 @@ func TestClean(t *testing.T) {
 
 func TestCompareStrings(t *testing.T) {
 	tests := []struct {
-		a, b string
-		want int
+		a, b  string
+		want  int
+		valid bool
 	}{
 		// Both invalid.
-		{"", "", 0},
-		{"a", "b", -1},
-		{"b", "a", 1},
-		{"nonsense", "hoo-hah", 1},
+		{"", "", 0, false},
+		{"a", "b", -1, false},
+		{"b", "a", 1, false},
+		{"nonsense", "hoo-hah", 1, false},
 
 		// One valid, one invalid.
-		{"12 angry cats", "6.2.4", -1},
-		{"v1.2", "nonesuch", 1},
+		{"12 angry cats", "6.2.4", -1, false},
+		{"v1.2", "nonesuch", 1, false},
+		{"2.0.0", "1.x", 1, false},
 
 		// Both valid.
-		{"v1", "1.0.0", 0},
-		{"1.2", "v1.2.0+extra", 0},
-		{"1", "1.0", 0},
-		{"v1.0.4-rc1", "1.0", 1},
-		{"v1-rc2", "1.0", -1},
-		{"v2-rc3", "2.0-rc2", 1},
+		{"v1", "1.0.0", 0, true},
+		{"1.2", "v1.2.0+extra", 0, true},
+		{"1", "1.0", 0, true},
+		{"v1.0.4-rc1", "1.0", 1, true},
+		{"v1-rc2", "1.0", -1, true},
+		{"v2-rc3", "2.0-rc2", 1, true},
+		{"10.0.0", "9.0.0", 1, true},
 	}
 	for _, tc := range tests {
 		got := semver.CompareStrings(tc.a, tc.b)
 		if got != tc.want {
 			t.Errorf("CompareStrings %q, %q: got %v, want %v", tc.a, tc.b, got, tc.want)
 		}
+		got, ok := semver.CompareStringsValid(tc.a, tc.b)
+		if got != tc.want || ok != tc.valid {
+			t.Errorf("CompareStringsValid %q, %q: got (%v, %v), want (%v, %v)",
+				tc.a, tc.b, got, ok, tc.want, tc.valid)
+		}
+	}
+}
+
+func TestCompareStringsLatest(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"latest", "9.9.9", 1},
+		{"9.9.9", "latest", -1},
+		{"latest", "LATEST", 0},
+		{" Latest\n", "v1000.0.0", 1},
+		{"latest", "zzz", 1},
+		{"lates", "1.0.0", 1}, // not the keyword, lexicographic fallback
+		{"v1.2", "1.2.0", 0},
+		{"1.0.4-rc1", "1.0.4", -1},
+	}
+	for _, tc := range tests {
+		got := semver.CompareStringsLatest(tc.a, tc.b)
+		if got != tc.want {
+			t.Errorf("CompareStringsLatest %q, %q: got %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+	// The plain comparison does not recognize the keyword.
+	if got := semver.CompareStrings("latest", "v9"); got != -1 {
+		t.Errorf("CompareStrings latest, v9: got %v, want -1", got)
+	}
+	if got := semver.CompareStringsLatest("latest", "v9"); got != 1 {
+		t.Errorf("CompareStringsLatest latest, v9: got %v, want 1", got)
+	}
+	if !semver.IsLatestKeyword("latest") || semver.IsLatestKeyword("1.0.0") {
+		t.Error("IsLatestKeyword did not recognize the keyword correctly")
+	}
+}
+
+func TestPredicates(t *testing.T) {
+	tests := []struct {
+		input       string
+		pre, stable bool
+	}{
+		{"0.0.0", false, false},
+		{"0.9.1", false, false},
+		{"0.9.1-rc1", true, false},
+		{"1.0.0", false, true},
+		{"1.0.0+build", false, true},
+		{"1.0.0-rc1", true, false},
+		{"1.0.0-rc1+build", true, false},
+		{"12.3.4", false, true},
+	}
+	for _, tc := range tests {
+		v := mustParse(t, tc.input)
+		if got := v.IsPrerelease(); got != tc.pre {
+			t.Errorf("[%v].IsPrerelease(): got %v, want %v", v, got, tc.pre)
+		}
+		if got := v.IsStable(); got != tc.stable {
+			t.Errorf("[%v].IsStable(): got %v, want %v", v, got, tc.stable)
+		}
 	}
 }
 
@@ -352,6 +1090,29 @@ func TestWithCore(t *testing.T) {
 	}
 }
 
+func TestWithField(t *testing.T) {
+	v := mustParse(t, "1.2.3-rc.1+b")
+	tests := []struct {
+		got  semver.V
+		want string
+	}{
+		{v.WithMajor(5), "5.2.3-rc.1+b"},
+		{v.WithMajor(0), "0.2.3-rc.1+b"},
+		{v.WithMinor(7), "1.7.3-rc.1+b"},
+		{v.WithPatch(0), "1.2.0-rc.1+b"},
+		{v.WithMajor(2).WithMinor(0).WithPatch(0), "2.0.0-rc.1+b"},
+		{semver.V{}.WithMinor(4), "0.4.0"},
+	}
+	for _, tc := range tests {
+		if got := tc.got.String(); got != tc.want {
+			t.Errorf("Got %q, want %q", got, tc.want)
+		}
+	}
+	mtest.MustPanicf(t, func() { v.WithMajor(-1) }, "WithMajor(-1) should panic")
+	mtest.MustPanicf(t, func() { v.WithMinor(-1) }, "WithMinor(-1) should panic")
+	mtest.MustPanicf(t, func() { v.WithPatch(-1) }, "WithPatch(-1) should panic")
+}
+
 func TestAdd(t *testing.T) {
 	tests := []struct {
 		input                  string
@@ -376,6 +1137,116 @@ func TestAdd(t *testing.T) {
 	}
 }
 
+func TestInc(t *testing.T) {
+	tests := []struct {
+		input, major, minor, patch string
+	}{
+		{"0.0.0", "1.0.0", "0.1.0", "0.0.1"},
+		{"1.2.3", "2.0.0", "1.3.0", "1.2.4"},
+		{"1.2.3-rc1", "2.0.0", "1.3.0", "1.2.4"},
+		{"1.2.3-rc1+b", "2.0.0", "1.3.0", "1.2.4"},
+		{"9.9.9+b", "10.0.0", "9.10.0", "9.9.10"},
+		{"99999999999999999999.9223372036854775807.18446744073709551615",
+			"100000000000000000000.0.0",
+			"99999999999999999999.9223372036854775808.0",
+			"99999999999999999999.9223372036854775807.18446744073709551616"},
+	}
+	for _, tc := range tests {
+		v := mustParse(t, tc.input)
+		if got := v.IncMajor(); got.String() != tc.major {
+			t.Errorf("[%v].IncMajor(): got %v, want %q", v, got, tc.major)
+		}
+		if got := v.IncMinor(); got.String() != tc.minor {
+			t.Errorf("[%v].IncMinor(): got %v, want %q", v, got, tc.minor)
+		}
+		if got := v.IncPatch(); got.String() != tc.patch {
+			t.Errorf("[%v].IncPatch(): got %v, want %q", v, got, tc.patch)
+		}
+	}
+}
+
+func TestLevel(t *testing.T) {
+	tests := []struct {
+		input string
+		want  semver.Level
+		bump  string // result of bumping 1.2.3-rc1+b
+	}{
+		{"major", semver.Major, "2.0.0"},
+		{"MINOR", semver.Minor, "1.3.0"},
+		{"Patch", semver.Patch, "1.2.4"},
+	}
+	v := mustParse(t, "1.2.3-rc1+b")
+	for _, tc := range tests {
+		got, err := semver.ParseLevel(tc.input)
+		if err != nil {
+			t.Errorf("ParseLevel(%q): unexpected error: %v", tc.input, err)
+			continue
+		} else if got != tc.want {
+			t.Errorf("ParseLevel(%q): got %v, want %v", tc.input, got, tc.want)
+		}
+		if s := got.String(); s != strings.ToLower(tc.input) {
+			t.Errorf("String(): got %q, want %q", s, strings.ToLower(tc.input))
+		}
+		if b := v.Bump(got); b.String() != tc.bump {
+			t.Errorf("[%v].Bump(%v): got %v, want %q", v, got, b, tc.bump)
+		}
+	}
+	for _, bad := range []string{"", "prerelease", "maj", " major"} {
+		if got, err := semver.ParseLevel(bad); err == nil {
+			t.Errorf("ParseLevel(%q): got %v, want error", bad, got)
+		}
+	}
+	if got := semver.Level(0).String(); got != "Level(0)" {
+		t.Errorf("Level(0).String(): got %q, want Level(0)", got)
+	}
+	mtest.MustPanicf(t, func() { v.Bump(0) }, "Bump with an invalid level should panic")
+}
+
+func TestClassifyChange(t *testing.T) {
+	tests := []struct {
+		old, new string
+		want     semver.Level
+	}{
+		{"1.2.3", "2.0.0", semver.Major},
+		{"1.2.3", "1.3.0", semver.Minor},
+		{"1.2.3", "1.2.4", semver.Patch},
+		{"1.2.3", "1.2.10", semver.Patch},
+		{"0.9.9", "1.0.0", semver.Major},
+		{"1.2.3", "2.0.0-rc.1", semver.Major},
+		{"1.9.0-rc.1", "2.0.0", semver.Major},
+		{"1.2.3-rc.1", "1.3.0", semver.Minor},
+		{"1.2.3-rc.1", "1.2.3-rc.2", semver.Patch},
+		{"1.2.3-rc.2", "1.2.3", semver.Patch},
+		{"1.2.3+a", "1.2.4+a", semver.Patch},
+		{"1.99999999999999999998.0", "1.99999999999999999999.0", semver.Minor},
+	}
+	for _, tc := range tests {
+		old, new := mustParse(t, tc.old), mustParse(t, tc.new)
+		got, err := semver.ClassifyChange(old, new)
+		if err != nil {
+			t.Errorf("ClassifyChange(%v, %v): unexpected error: %v", old, new, err)
+		} else if got != tc.want {
+			t.Errorf("ClassifyChange(%v, %v): got %v, want %v", old, new, got, tc.want)
+		}
+	}
+
+	bad := []struct {
+		old, new string
+	}{
+		{"1.2.3", "1.2.3"},
+		{"1.2.3", "1.2.3+b"},
+		{"1.2.4", "1.2.3"},
+		{"2.0.0", "1.9.9"},
+		{"1.2.3", "1.2.3-rc.1"},
+	}
+	for _, tc := range bad {
+		old, new := mustParse(t, tc.old), mustParse(t, tc.new)
+		if got, err := semver.ClassifyChange(old, new); !errors.Is(err, semver.ErrNotIncreasing) {
+			t.Errorf("ClassifyChange(%v, %v): got (%v, %v), want %v", old, new, got, err, semver.ErrNotIncreasing)
+		}
+	}
+}
+
 func TestKey(t *testing.T) {
 	tests := []struct {
 		input, want string
@@ -399,6 +1270,135 @@ func TestKey(t *testing.T) {
 	}
 }
 
+func TestPrecedenceString(t *testing.T) {
+	tests := []struct {
+		input, want string
+	}{
+		{"1.2.3", "1.2.3"},
+		{"1.2.3+build", "1.2.3"},
+		{"1.2.3-rc.1", "1.2.3-rc.1"},
+		{"1.2.3-rc.1+build.5", "1.2.3-rc.1"},
+	}
+	for _, tc := range tests {
+		v := mustParse(t, tc.input)
+		if got := v.PrecedenceString(); got != tc.want {
+			t.Errorf("[%v].PrecedenceString(): got %q, want %q", v, got, tc.want)
+		}
+	}
+
+	a, b := mustParse(t, "1.2.3-rc.1+x"), mustParse(t, "1.2.3-rc.1+y")
+	if pa, pb := a.PrecedenceString(), b.PrecedenceString(); pa != pb {
+		t.Errorf("PrecedenceString: %v gives %q, %v gives %q", a, pa, b, pb)
+	}
+}
+
+func TestContentKey(t *testing.T) {
+	tests := []struct {
+		input, want string
+	}{
+		{"1.2.3", "1.2.3"},
+		{"1.2.3-rc.2.1", "1.2.3-rc.2.1"},
+		{"1.2.3+a.b", "1.2.3+a.b"},
+		{"1.2.3+b.a", "1.2.3+a.b"},
+		{"1.2.3+b.a.b.a", "1.2.3+a.b"},
+		{"1.2.3-z.y+c.10.2", "1.2.3-z.y+10.2.c"},
+	}
+	for _, tc := range tests {
+		v := mustParse(t, tc.input)
+		if got := v.ContentKey(); got != tc.want {
+			t.Errorf("[%v].ContentKey(): got %q, want %q", v, got, tc.want)
+		}
+	}
+
+	ab, ba, ac := mustParse(t, "1.2.3+a.b"), mustParse(t, "1.2.3+b.a"), mustParse(t, "1.2.3+a.c")
+	if ab.ContentKey() != ba.ContentKey() {
+		t.Errorf("ContentKey: %v and %v differ", ab, ba)
+	}
+	if ab.ContentKey() == ac.ContentKey() {
+		t.Errorf("ContentKey: %v and %v are the same", ab, ac)
+	}
+	if got := (semver.V{}).ContentKey(); got != "0.0.0" {
+		t.Errorf("Zero ContentKey: got %q, want 0.0.0", got)
+	}
+}
+
+func TestWouldCollide(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"1.2.3", "1.2.3", true},
+		{"1.2.3+a", "1.2.3+b", true},
+		{"1.2.3-rc1+a", "1.2.3-rc1", true},
+		{"1.2.3", "1.2.4", false},
+		{"1.2.3-rc1", "1.2.3", false},
+		{"1.2.3-rc1+a", "1.2.3-rc2+a", false},
+	}
+	for _, tc := range tests {
+		a, b := mustParse(t, tc.a), mustParse(t, tc.b)
+		if got := semver.WouldCollide(a, b); got != tc.want {
+			t.Errorf("WouldCollide(%v, %v): got %v, want %v", a, b, got, tc.want)
+		}
+	}
+}
+
+func TestCompatible(t *testing.T) {
+	tests := []struct {
+		v, w string
+		want bool
+	}{
+		{"1.2.3", "1.2.3", true},
+		{"1.2.3", "1.2.3+b", true},
+		{"1.2.3", "1.2.4", true},
+		{"1.2.3", "1.9.0", true},
+		{"1.2.3", "1.3.0-rc.1", true},
+		{"1.2.3", "1.2.2", false},
+		{"1.2.3", "1.2.3-rc.1", false},
+		{"1.2.3", "2.0.0", false},
+		{"1.0.0-rc.1", "1.0.0", true},
+		{"0.2.3", "0.2.9", true},
+		{"0.2.3", "0.3.0", false},
+		{"0.2.3", "1.2.3", false},
+		{"0.0.3", "0.0.4", true},
+		{"0.0.3", "0.1.0", false},
+		{"2.0.0", "1.9.9", false},
+	}
+	for _, tc := range tests {
+		v, w := mustParse(t, tc.v), mustParse(t, tc.w)
+		if got := v.Compatible(w); got != tc.want {
+			t.Errorf("[%v].Compatible(%v): got %v, want %v", v, w, got, tc.want)
+		}
+	}
+}
+
+func TestCompatibleWithAny(t *testing.T) {
+	tests := []struct {
+		v    string
+		vs   []string
+		want string // "" means no match
+	}{
+		{"1.2.3", nil, ""},
+		{"1.2.3", []string{"2.0.0", "3.1.0"}, ""},
+		{"1.2.3", []string{"2.0.0", "1.4.0", "1.5.0"}, "1.4.0"},
+		{"1.2.3", []string{"1.2.2", "1.2.3+b"}, "1.2.3+b"},
+		{"1.2.3", []string{"1.2.3-rc1", "0.9.0"}, ""},
+		{"0.2.3", []string{"0.3.0", "1.0.0", "0.2.9"}, "0.2.9"},
+		{"0.2.3", []string{"0.3.0", "0.1.0"}, ""},
+		{"1.0.0-rc1", []string{"1.0.0"}, "1.0.0"},
+	}
+	for _, tc := range tests {
+		v := mustParse(t, tc.v)
+		var vs []semver.V
+		for _, s := range tc.vs {
+			vs = append(vs, mustParse(t, s))
+		}
+		got, ok := v.CompatibleWithAny(vs)
+		if ok != (tc.want != "") || (ok && got.String() != tc.want) {
+			t.Errorf("[%v].CompatibleWithAny(%q): got (%v, %v), want %q", v, tc.vs, got, ok, tc.want)
+		}
+	}
+}
+
 func TestMarshalText(t *testing.T) {
 	tests := []struct {
 		input semver.V
@@ -452,6 +1452,20 @@ func TestUnmarshalText(t *testing.T) {
 	}
 }
 
+func TestParseAllocs(t *testing.T) {
+	// A successful Parse returns substrings of its input, and should never
+	// allocate. This is also checked by BenchmarkParse, but benchmarks are not
+	// run by default.
+	for _, s := range []string{
+		"0.0.0", "1.2.3", "1.2.3-rc.1", "1.2.3+build.5", "1.2.3-alpha.1.beta+x-y.z",
+		"123456789.9876543210.1234567890000-alpha.bravo.charlie+a.123.456789a",
+	} {
+		if na := testing.AllocsPerRun(100, func() { semver.Parse(s) }); na != 0 {
+			t.Errorf("Parse %q: got %.1f allocations, want 0", s, na)
+		}
+	}
+}
+
 func BenchmarkParse(b *testing.B) {
 	benchInput := func(input string, parse func(string) (semver.V, error)) func(b *testing.B) {
 		return func(b *testing.B) {
@@ -486,6 +1500,17 @@ func BenchmarkParse(b *testing.B) {
 	checkAlloc("Valid strict", 0, func() { semver.Parse(long) })
 	checkAlloc("Valid lax", 0, func() { semver.ParseClean(" v" + long + "\n") })
 
+	// ParseBytes must copy its input, but should not otherwise allocate.
+	longBytes := []byte(long)
+	b.Run("Bytes/Long", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := semver.ParseBytes(longBytes); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	checkAlloc("Valid bytes", 1, func() { semver.ParseBytes(longBytes) })
+
 	// Lax parses of "dirty" inputs (those requiring non-trivial cleaning) are
 	// generally expected to cause allocations.
 	b.Run("Lax/Dirty/Short", benchInput(dirty, semver.ParseClean))
@@ -532,4 +1557,345 @@ func BenchmarkCompare(b *testing.B) {
 			_ = semver.Compare(v1, v2)
 		}
 	})
+
+	// Compare walks the release labels in place, without splitting them, so
+	// sorting does not allocate beyond the copy of the input.
+	if na := testing.AllocsPerRun(1000, func() { semver.Compare(v1, v2) }); na != 0 {
+		b.Errorf("Compare: got %f allocations, want 0", na)
+	}
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	labels := []string{"", "", "alpha", "beta.1", "rc.1", "rc.2", "rc.10", "1.2.x", "pre.11.b"}
+	vs := make([]semver.V, 10000)
+	for i := range vs {
+		vs[i] = semver.New(rng.IntN(5), rng.IntN(20), rng.IntN(50)).
+			WithRelease(labels[rng.IntN(len(labels))])
+	}
+	b.Run("Sort", func(b *testing.B) {
+		buf := make([]semver.V, len(vs))
+		for b.Loop() {
+			copy(buf, vs)
+			slices.SortFunc(buf, semver.Compare)
+		}
+	})
+}
+
+func TestParseFieldLimits(t *testing.T) {
+	tests := []struct {
+		input   string
+		max     int
+		errText string
+	}{
+		{"1.2.3", 0, ""},
+		{"123456789.0.0", 0, ""},
+		{"123.456.789-rc1+b", 3, ""},
+		{"1234.5.6", 3, `invalid major "1234": too many digits (got 4, max 3)`},
+		{"1.2345.6", 3, `invalid minor "2345": too many digits (got 4, max 3)`},
+		{"1.2.3456", 3, `invalid patch "3456": too many digits (got 4, max 3)`},
+		{"999999.0.1", 6, ""},
+		{"0.1000000.1", 6, `invalid minor "1000000": too many digits (got 7, max 6)`},
+		{"1.2", 6, "wrong length"},
+		{"1234.5.6", -1, ""},
+	}
+	for _, tc := range tests {
+		v, err := semver.ParseFieldLimits(tc.input, tc.max)
+		if tc.errText == "" {
+			if err != nil {
+				t.Errorf("ParseFieldLimits(%q, %d): unexpected error: %v", tc.input, tc.max, err)
+			} else if got := v.String(); got != tc.input {
+				t.Errorf("ParseFieldLimits(%q, %d): got %q, want %q", tc.input, tc.max, got, tc.input)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tc.errText) {
+			t.Errorf("ParseFieldLimits(%q, %d): got (%v, %v), want error %q", tc.input, tc.max, v, err, tc.errText)
+		}
+	}
+
+	// Width errors report the field and its offset.
+	offsets := []struct {
+		input  string
+		field  string
+		offset int
+	}{
+		{"1234.5.6", "major", 0},
+		{"1.2345.6", "minor", 2},
+		{"12.34.5678-rc", "patch", 6},
+	}
+	for _, tc := range offsets {
+		_, err := semver.ParseFieldLimits(tc.input, 3)
+		var perr *semver.ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("ParseFieldLimits(%q, 3): got %v, want *ParseError", tc.input, err)
+		} else if perr.Field != tc.field || perr.Offset != tc.offset {
+			t.Errorf("ParseFieldLimits(%q, 3): got %s at %d, want %s at %d", tc.input, perr.Field, perr.Offset, tc.field, tc.offset)
+		}
+	}
+}
+
+func TestNextInWorkflow(t *testing.T) {
+	tests := []struct {
+		input, label, want string
+		back               bool
+	}{
+		{"1.2.3", "rc", "1.2.4-rc.1", false},
+		{"1.2.3+build", "rc", "1.2.4-rc.1", false},
+		{"1.2.4-rc.1", "rc", "1.2.4-rc.2", false},
+		{"1.2.4-rc.9+x", "rc", "1.2.4-rc.10", false},
+		{"0.0.0", "alpha", "0.0.1-alpha.1", false},
+		{"1.2.4-beta", "rc", "1.2.4-rc.1", false},
+		{"1.2.4-beta.3", "rc", "1.2.4-rc.1", false},
+		{"1.2.4-rc.x", "rc", "1.2.4-rc.1", true},
+		{"1.2.4-rc.3.1", "rc", "1.2.4-rc.1", true},
+		{"1.2.4-rc.1", "beta", "1.2.4-beta.1", true},
+	}
+	for _, tc := range tests {
+		v := mustParse(t, tc.input)
+		got := v.NextInWorkflow(tc.label)
+		if got.String() != tc.want {
+			t.Errorf("[%v].NextInWorkflow(%q): got %q, want %q", v, tc.label, got, tc.want)
+		}
+
+		// The result moves forward unless a new sequence sorts before v.
+		if fwd := got.After(v); fwd == tc.back {
+			t.Errorf("[%v].NextInWorkflow(%q): got %v after input = %v, want %v", v, tc.label, got, fwd, !tc.back)
+		}
+	}
+
+	for _, label := range []string{"", "bad label!", "rc..1", "rc.01", "rc+x"} {
+		mtest.MustPanicf(t, func() { semver.New(1, 2, 3).NextInWorkflow(label) },
+			"NextInWorkflow(%q) should panic", label)
+	}
+}
+
+func TestNextPrerelease(t *testing.T) {
+	tests := []struct {
+		input, next, final string
+	}{
+		{"1.2.3", "1.2.4-1", "1.2.3"},
+		{"1.2.3+b", "1.2.4-1", "1.2.3+b"},
+		{"1.2.3-rc.1", "1.2.3-rc.2", "1.2.3"},
+		{"1.2.3-rc.9+b", "1.2.3-rc.10", "1.2.3+b"},
+		{"1.2.3-4", "1.2.3-5", "1.2.3"},
+		{"1.2.3-rc", "1.2.3-rc.1", "1.2.3"},
+		{"1.2.3-rc1", "1.2.3-rc1.1", "1.2.3"},
+		{"1.2.3-alpha.2.beta", "1.2.3-alpha.2.beta.1", "1.2.3"},
+		{"1.2.3-x.99999999999999999999", "1.2.3-x.100000000000000000000", "1.2.3"},
+	}
+	for _, tc := range tests {
+		v := mustParse(t, tc.input)
+		next := v.NextPrerelease()
+		if got := next.String(); got != tc.next {
+			t.Errorf("[%v].NextPrerelease(): got %q, want %q", v, got, tc.next)
+		}
+		if !next.After(v) {
+			t.Errorf("[%v].NextPrerelease(): %v does not follow input", v, next)
+		}
+		if got := v.Finalize().String(); got != tc.final {
+			t.Errorf("[%v].Finalize(): got %q, want %q", v, got, tc.final)
+		}
+	}
+}
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		input          string
+		release, build []string
+	}{
+		{"1.2.3", nil, nil},
+		{"1.2.3-rc1.4", []string{"rc1", "4"}, nil},
+		{"1.2.3+x", nil, []string{"x"}},
+		{"1.2.3-a.b-c.0+d.01.e", []string{"a", "b-c", "0"}, []string{"d", "01", "e"}},
+	}
+	for _, tc := range tests {
+		v := mustParse(t, tc.input)
+		if got := v.ReleaseParts(); !slices.Equal(got, tc.release) {
+			t.Errorf("[%v].ReleaseParts(): got %q, want %q", v, got, tc.release)
+		}
+		if got := v.BuildParts(); !slices.Equal(got, tc.build) {
+			t.Errorf("[%v].BuildParts(): got %q, want %q", v, got, tc.build)
+		}
+	}
+
+	// Setting parts.
+	sets := []struct {
+		release, build []string
+		want           string
+	}{
+		{nil, nil, "1.2.3"},
+		{[]string{"rc1", "4"}, nil, "1.2.3-rc1.4"},
+		{[]string{"", "a", "", "b", ""}, []string{"x", ""}, "1.2.3-a.b+x"},
+		{[]string{""}, []string{"", ""}, "1.2.3"},
+	}
+	for _, tc := range sets {
+		v := mustParse(t, "1.2.3-old+old").WithReleaseParts(tc.release...).WithBuildParts(tc.build...)
+		if got := v.String(); got != tc.want {
+			t.Errorf("WithReleaseParts(%q).WithBuildParts(%q): got %q, want %q", tc.release, tc.build, got, tc.want)
+		}
+		if w := v.WithReleaseParts(v.ReleaseParts()...).WithBuildParts(v.BuildParts()...); w != v {
+			t.Errorf("Parts round trip: got %v, want %v", w, v)
+		}
+	}
+
+	// Modifying the result does not affect the version.
+	v := mustParse(t, "1.0.0-a.b+c.d")
+	v.ReleaseParts()[0] = "x"
+	v.BuildParts()[0] = "y"
+	if got := v.String(); got != "1.0.0-a.b+c.d" {
+		t.Errorf("After modifying parts: got %q, want 1.0.0-a.b+c.d", got)
+	}
+}
+
+func TestBuildField(t *testing.T) {
+	tests := []struct {
+		input, key string
+		want       string
+		ok         bool
+	}{
+		{"1.2.3", "build", "", false},
+		{"1.2.3+build.123.sha.abc", "build", "123", true},
+		{"1.2.3+build.123.sha.abc", "sha", "abc", true},
+		{"1.2.3-rc1+build.123.sha.abc", "other", "", false},
+		{"1.2.3+build.123.sha.abc", "123", "", false}, // values are not keys
+		{"1.2.3+build.123.sha", "build", "", false},   // odd number of words
+		{"1.2.3+build", "build", "", false},
+		{"1.2.3+k.1.k.2", "k", "1", true},
+	}
+	for _, tc := range tests {
+		v := mustParse(t, tc.input)
+		got, ok := v.BuildField(tc.key)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("[%v].BuildField(%q): got (%q, %v), want (%q, %v)", v, tc.key, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestAsMapKey(t *testing.T) {
+	m := make(map[semver.MapKey][]string)
+	for _, s := range []string{
+		"1.2.3", "1.2.3+a", "1.2.3+b.c", "1.2.3-rc1", "1.2.3-rc1+x", "1.2.4", "0.0.0",
+	} {
+		k := mustParse(t, s).AsMapKey()
+		m[k] = append(m[k], s)
+	}
+	// The zero value is equivalent to 0.0.0, so shares its key.
+	m[semver.V{}.AsMapKey()] = append(m[semver.V{}.AsMapKey()], "zero")
+
+	want := map[string][]string{
+		"1.2.3":     {"1.2.3", "1.2.3+a", "1.2.3+b.c"},
+		"1.2.3-rc1": {"1.2.3-rc1", "1.2.3-rc1+x"},
+		"1.2.4":     {"1.2.4"},
+		"0.0.0":     {"0.0.0", "zero"},
+	}
+	if len(m) != len(want) {
+		t.Errorf("Got %d keys, want %d: %v", len(m), len(want), m)
+	}
+	for k, vs := range m {
+		if got := strings.Join(vs, ","); got != strings.Join(want[k.String()], ",") {
+			t.Errorf("Key %v: got %q, want %q", k, got, want[k.String()])
+		}
+		if k.V().Build() != "" {
+			t.Errorf("Key %v: has build metadata %q", k, k.V().Build())
+		}
+	}
+}
+
+func TestRequireBuild(t *testing.T) {
+	tests := []struct {
+		input   string
+		errText string
+	}{
+		{"1.2.3", "missing build metadata"},
+		{"1.2.3-rc1", "missing build metadata"},
+		{"1.2.3+b", ""},
+		{"1.2.3-rc1+git.abc123", ""},
+		{"1.2.3+", "empty build metadata"},
+		{"1.2", "wrong length"},
+	}
+	for _, tc := range tests {
+		v, err := semver.ParseRequireBuild(tc.input)
+		if tc.errText != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.errText) {
+				t.Errorf("ParseRequireBuild(%q): got (%v, %v), want error %q", tc.input, v, err, tc.errText)
+			}
+		} else if err != nil {
+			t.Errorf("ParseRequireBuild(%q): unexpected error: %v", tc.input, err)
+		} else if v.String() != tc.input {
+			t.Errorf("ParseRequireBuild(%q): got %v, want %q", tc.input, v, tc.input)
+		}
+	}
+	if err := semver.RequireBuild(semver.New(1, 0, 0)); err == nil {
+		t.Error("RequireBuild(1.0.0): got nil, want error")
+	}
+	if err := semver.RequireBuild(semver.New(1, 0, 0).WithBuild("x")); err != nil {
+		t.Errorf("RequireBuild(1.0.0+x): unexpected error: %v", err)
+	}
+}
+
+func TestPublishable(t *testing.T) {
+	tests := []struct {
+		input      semver.V
+		want       []string
+		allowBuild bool // the result of IsPublishableAllowBuild
+	}{
+		{semver.V{}, nil, true},
+		{semver.MustParse("1.2.3"), nil, true},
+		{semver.MustParse("1.2.3-rc.1"), []string{"has prerelease rc.1"}, false},
+		{semver.MustParse("1.2.3+b.5"), []string{"has build metadata b.5"}, true},
+		{semver.MustParse("1.2.3-rc.1+b.5"), []string{
+			"has prerelease rc.1", "has build metadata b.5",
+		}, false},
+		{semver.New(1, 2, 3).WithRelease("bad label"), []string{
+			`invalid release "bad label": invalid char (pos 1)`, "has prerelease bad label",
+		}, false},
+		{semver.New(1, 2, 3).WithBuild("bad build"), []string{
+			`invalid build "bad build": invalid char (pos 1)`, "has build metadata bad build",
+		}, false},
+	}
+	for _, tc := range tests {
+		errs := tc.input.PublishErrors()
+		var got []string
+		for _, err := range errs {
+			got = append(got, err.Error())
+		}
+		if strings.Join(got, "|") != strings.Join(tc.want, "|") {
+			t.Errorf("[%v].PublishErrors(): got %q, want %q", tc.input, got, tc.want)
+		}
+		if ok := semver.IsPublishable(tc.input); ok != (len(tc.want) == 0) {
+			t.Errorf("IsPublishable(%v): got %v, want %v", tc.input, ok, !ok)
+		}
+		if ok := semver.IsPublishableAllowBuild(tc.input); ok != tc.allowBuild {
+			t.Errorf("IsPublishableAllowBuild(%v): got %v, want %v", tc.input, ok, tc.allowBuild)
+		}
+	}
+	if v := semver.MustParse("1.2.3+b"); !semver.IsPublishable(v.Key()) {
+		t.Errorf("IsPublishable(%v.Key()): got false, want true", v)
+	}
+}
+
+func TestHasLeadingZeros(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"", false},
+		{"0.0.0", false},
+		{"1.0.0-rc.0", false},
+		{"1.0.0-rc.01", true},
+		{"1.0.0+01", false},
+		{"1.0.0-rc.1+001.02", false},
+		{"01.0.0", true},
+		{"1.00.0", true},
+		{"1.0.00", true},
+		{"1.2", false},
+		{"1.02", true},
+		{"1.0.0-0a", false}, // not numeric
+		{"1.0.0-a.b.00", true},
+		{"v1.0.0-x.007+x", true},
+		{"v01.2.3", true},
+		{"v1.2.3", false},
+	}
+	for _, tc := range tests {
+		if got := semver.HasLeadingZeros(tc.input); got != tc.want {
+			t.Errorf("HasLeadingZeros(%q): got %v, want %v", tc.input, got, tc.want)
+		}
+	}
 }
@@ -0,0 +1,138 @@
+// Copyright (C) 2026 Michael J. Fromberger. All Rights Reserved.
+
+// Package semvertest provides support code for testing programs that order
+// or store semantic versions.
+package semvertest
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/creachadair/semver"
+)
+
+// CheckOrdering verifies that [semver.Compare] is a strict weak ordering over
+// the given sample of versions. It reports an error describing the first
+// violation found, or nil if none was found. See [CheckOrderingFunc].
+func CheckOrdering(vs []semver.V) error { return CheckOrderingFunc(vs, semver.Compare) }
+
+// CheckOrderingFunc verifies that compare is a strict weak ordering over the
+// given sample of versions. Specifically, it checks that for all a, b, and c
+// in vs:
+//
+//   - compare(a, a) == 0 (reflexivity of equivalence)
+//   - compare(a, b) and compare(b, a) have opposite signs (antisymmetry and
+//     totality)
+//   - compare(a, b) <= 0 and compare(b, c) <= 0 imply compare(a, c) <= 0
+//     (transitivity)
+//
+// It reports an error describing the first violation found, or nil.
+// The cost of the check is cubic in len(vs).
+func CheckOrderingFunc(vs []semver.V, compare func(a, b semver.V) int) error {
+	for _, a := range vs {
+		if c := compare(a, a); c != 0 {
+			return fmt.Errorf("not reflexive: compare(%v, %v) = %d", a, a, c)
+		}
+	}
+	for _, a := range vs {
+		for _, b := range vs {
+			ab, ba := compare(a, b), compare(b, a)
+			if sign(ab) != -sign(ba) {
+				return fmt.Errorf("not antisymmetric: compare(%v, %v) = %d, compare(%v, %v) = %d",
+					a, b, ab, b, a, ba)
+			}
+		}
+	}
+	for _, a := range vs {
+		for _, b := range vs {
+			ab := compare(a, b)
+			if ab > 0 {
+				continue
+			}
+			for _, c := range vs {
+				bc := compare(b, c)
+				if bc > 0 {
+					continue
+				}
+				if ac := compare(a, c); ac > 0 {
+					return fmt.Errorf("not transitive: compare(%v, %v) = %d, compare(%v, %v) = %d, compare(%v, %v) = %d",
+						a, b, ab, b, c, bc, a, c, ac)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func sign(c int) int { return cmp.Compare(c, 0) }
+
+// Random returns a pseudo-random valid version chosen using r. Core version
+// numbers are usually small, but are occasionally large. About a third of the
+// results have a release label, and about a quarter have build metadata.
+// Each label has one to three words, mixing numeric and alphanumeric words.
+//
+// Every result round-trips through [semver.V.String] and [semver.Parse].
+// Random is intended for property-based tests of code that consumes
+// versions. To use it with testing/quick, whose generators use math/rand,
+// derive r from the generator provided, for example:
+//
+//	r := rand.New(rand.NewPCG(uint64(qr.Int63()), 0))
+func Random(r *rand.Rand) semver.V {
+	var sb strings.Builder
+	for i := range 3 {
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(strconv.Itoa(randomNum(r)))
+	}
+	if r.IntN(3) == 0 {
+		sb.WriteByte('-')
+		writeRandomWords(&sb, r, false)
+	}
+	if r.IntN(4) == 0 {
+		sb.WriteByte('+')
+		writeRandomWords(&sb, r, true)
+	}
+	return semver.MustParse(sb.String())
+}
+
+// randomNum returns a pseudo-random non-negative version number, usually
+// small.
+func randomNum(r *rand.Rand) int {
+	if r.IntN(10) == 0 {
+		return r.IntN(1_000_000)
+	}
+	return r.IntN(12)
+}
+
+// wordChars are the characters permitted in release and build words.
+const wordChars = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ-"
+
+// writeRandomWords writes one to three dot-separated pseudo-random words to
+// sb. If zeroes is false, numeric words do not have leading zeroes, as
+// required for release labels.
+func writeRandomWords(sb *strings.Builder, r *rand.Rand, zeroes bool) {
+	for i := range 1 + r.IntN(3) {
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+		if r.IntN(2) == 0 {
+			n := strconv.Itoa(randomNum(r))
+			if zeroes && r.IntN(4) == 0 {
+				n = "0" + n
+			}
+			sb.WriteString(n)
+			continue
+		}
+		// An alphanumeric word: include at least one non-digit.
+		w := []byte{"abcdefghijklmnopqrstuvwxyz-"[r.IntN(27)]}
+		for range r.IntN(6) {
+			w = append(w, wordChars[r.IntN(len(wordChars))])
+		}
+		r.Shuffle(len(w), func(i, j int) { w[i], w[j] = w[j], w[i] })
+		sb.Write(w)
+	}
+}
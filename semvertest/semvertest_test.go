@@ -0,0 +1,129 @@
+// Copyright (C) 2026 Michael J. Fromberger. All Rights Reserved.
+
+package semvertest_test
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/creachadair/semver"
+	"github.com/creachadair/semver/semvertest"
+)
+
+var sample = []semver.V{
+	semver.MustParse("0.0.0"),
+	semver.MustParse("0.0.1"),
+	semver.MustParse("1.0.0-alpha"),
+	semver.MustParse("1.0.0-alpha.1"),
+	semver.MustParse("1.0.0-alpha.beta"),
+	semver.MustParse("1.0.0-beta.2"),
+	semver.MustParse("1.0.0-beta.11"),
+	semver.MustParse("1.0.0-rc.1"),
+	semver.MustParse("1.0.0"),
+	semver.MustParse("1.0.0+build"),
+	semver.MustParse("1.2.3"),
+	semver.MustParse("1.10.0"),
+	semver.MustParse("2.0.0+x.y"),
+}
+
+func TestCheckOrdering(t *testing.T) {
+	if err := semvertest.CheckOrdering(sample); err != nil {
+		t.Errorf("CheckOrdering: unexpected error: %v", err)
+	}
+	if err := semvertest.CheckOrdering(nil); err != nil {
+		t.Errorf("CheckOrdering(nil): unexpected error: %v", err)
+	}
+}
+
+func TestCheckOrderingFunc(t *testing.T) {
+	tests := []struct {
+		name    string
+		vs      []semver.V // if nil, use sample
+		compare func(a, b semver.V) int
+		errText string
+	}{
+		{"Compare", nil, semver.Compare, ""},
+
+		// Build metadata are included in the string, so this is a valid (but
+		// different) ordering.
+		{"Strings", nil, func(a, b semver.V) int {
+			return strings.Compare(a.String(), b.String())
+		}, ""},
+
+		{"Irreflexive", nil, func(a, b semver.V) int { return -1 }, "not reflexive"},
+		{"Asymmetric", nil, func(a, b semver.V) int {
+			if a == b {
+				return 0
+			}
+			return 1 // everything is greater than everything else
+		}, "not antisymmetric"},
+		{"Intransitive", []semver.V{
+			semver.New(0, 0, 0), semver.New(1, 0, 0), semver.New(2, 0, 0),
+		}, func(a, b semver.V) int {
+			// Rock, paper, scissors on the major version.
+			x, y := a.Major()%3, b.Major()%3
+			switch {
+			case x == y:
+				return 0
+			case (x+1)%3 == y:
+				return -1
+			default:
+				return 1
+			}
+		}, "not transitive"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			vs := tc.vs
+			if vs == nil {
+				vs = sample
+			}
+			err := semvertest.CheckOrderingFunc(vs, tc.compare)
+			if tc.errText == "" {
+				if err != nil {
+					t.Errorf("CheckOrderingFunc: unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tc.errText) {
+				t.Errorf("CheckOrderingFunc: got %v, want error %q", err, tc.errText)
+			}
+		})
+	}
+}
+
+func TestRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	var vs []semver.V
+	var nRelease, nBuild int
+	for range 1000 {
+		v := semvertest.Random(r)
+		if w, err := semver.Parse(v.String()); err != nil {
+			t.Errorf("Parse(%q): unexpected error: %v", v, err)
+		} else if w != v {
+			t.Errorf("Parse(%q): got %#v, want %#v", v, w, v)
+		}
+		if v.IsPrerelease() {
+			nRelease++
+		}
+		if v.Build() != "" {
+			nBuild++
+		}
+		if len(vs) < 40 {
+			vs = append(vs, v)
+		}
+	}
+	if nRelease == 0 || nBuild == 0 {
+		t.Errorf("Random: got %d with release and %d with build, want some of each", nRelease, nBuild)
+	}
+	if err := semvertest.CheckOrdering(vs); err != nil {
+		t.Errorf("CheckOrdering: unexpected error: %v", err)
+	}
+
+	// The same seed yields the same sequence.
+	r1, r2 := rand.New(rand.NewPCG(3, 4)), rand.New(rand.NewPCG(3, 4))
+	for range 10 {
+		if a, b := semvertest.Random(r1), semvertest.Random(r2); a != b {
+			t.Errorf("Random: got %v and %v from the same seed", a, b)
+		}
+	}
+}